@@ -3,11 +3,14 @@ package main
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/creack/pty"
@@ -22,6 +25,11 @@ type commandInfo struct {
 	env     []string
 	command string
 	args    []string
+
+	// dir is the working directory of the command, the current one when empty
+	dir string
+	// extraEnv is added to the environment inherited from the current process
+	extraEnv []string
 }
 
 func newCommandInfo(inputs ...string) *commandInfo {
@@ -40,14 +48,10 @@ func newCommandInfo(inputs ...string) *commandInfo {
 	}
 
 	if len(args) == 0 {
-		return &commandInfo{
-			env, "", nil,
-		}
+		return &commandInfo{env: env}
 	}
 
-	return &commandInfo{
-		env, args[0], args[1:],
-	}
+	return &commandInfo{env: env, command: args[0], args: args[1:]}
 }
 
 func unquotedFlatten(inputs ...string) (out []string) {
@@ -71,7 +75,11 @@ func (i *commandInfo) RunSplit() (stdOut string, stdErr string, combined string,
 
 func (i *commandInfo) ToCommand() *exec.Cmd {
 	cmd := exec.Command(i.command, i.args...)
+	cmd.Dir = i.dir
 	cmd.Env = i.env
+	if len(i.extraEnv) > 0 {
+		cmd.Env = append(append(os.Environ(), i.extraEnv...), i.env...)
+	}
 
 	return cmd
 }
@@ -104,21 +112,32 @@ func maybeRun(inputs ...string) (output string, info *commandInfo, err error) {
 }
 
 func internalMaybeRun(inputs []string, silent bool) (output string, info *commandInfo, err error) {
+	info = newCommandInfo(inputs...)
+	output, err = runCommand(info, silent, 0)
+
+	return output, info, err
+}
+
+// runCommand runs `info` printing its output unless `silent`, the command is killed
+// if it's still running after `timeout`, no timeout applies when it's 0.
+func runCommand(info *commandInfo, silent bool, timeout time.Duration) (output string, err error) {
 	startTime := time.Now()
 
 	defer func() {
-		var fields []zap.Field
-		if info != nil {
-			fields = append(fields, zap.Stringer("command", info))
-		}
-		fields = append(fields, zap.Duration("took", time.Since(startTime)), zap.Bool("success", err == nil))
+		zlog.Debug("run of command terminated", zap.Stringer("command", info), zap.Duration("took", time.Since(startTime)), zap.Bool("success", err == nil))
 
-		zlog.Debug("run of command terminated", fields...)
+		if !isDryRun() {
+			emitEvent(&outputEvent{Type: eventCommand, Command: info.String(), DurationMs: time.Since(startTime).Milliseconds(), Success: ptr(err == nil)})
+		}
 	}()
 
-	info = newCommandInfo(inputs...)
 	cli.Ensure(info.command != "", "Must have at least command to run")
 
+	if isDryRun() {
+		activePlan.Command(info)
+		return "", nil
+	}
+
 	// FIXME: What to do with error where program would like to receive data written to terminal,
 	// for example for input?
 
@@ -132,11 +151,23 @@ func internalMaybeRun(inputs []string, silent bool) (output string, info *comman
 	cmd := info.ToCommand()
 	writer := io.MultiWriter(outputWriter, captured)
 
+	var ptyFile *os.File
+	var copyDone chan struct{}
+	var copyErr error
+
+	if timeout > 0 {
+		// Processes started by the command in the background could keep its output open
+		cmd.WaitDelay = commandWaitDelay
+	}
+
 	if ptyDisabled {
 		zlog.Debug("starting command", zap.Stringer("cmd", info))
 
 		cmd.Stdout = writer
 		cmd.Stderr = writer
+		if timeout > 0 {
+			setProcessGroup(cmd)
+		}
 
 		err := cmd.Start()
 		cli.NoError(err, "Unable to start command")
@@ -144,20 +175,81 @@ func internalMaybeRun(inputs []string, silent bool) (output string, info *comman
 	} else {
 		zlog.Debug("starting command through PTY", zap.Stringer("cmd", info))
 
-		ptyFile, err := pty.Start(cmd)
+		// A new process group instead of the new session of [pty.Start], Linux discards the output
+		// not yet read from the PTY when the leader of the session controlling it exits.
+		setProcessGroup(cmd)
+
+		ptyFile, err = pty.StartWithAttrs(cmd, nil, cmd.SysProcAttr)
 		cli.NoError(err, "Unable to start command through PTY")
 		defer ptyFile.Close()
 
+		copyDone = make(chan struct{})
 		go func() {
+			defer close(copyDone)
+
 			zlog.Debug("starting copy of process pty output to stdout")
-			_, err = io.Copy(writer, ptyFile)
-			cli.NoError(err, "Unable to copy command PTY to stdout")
+			_, err := io.Copy(writer, ptyFile)
+
+			// Reading the PTY fails with EIO once the command (and all it started) closed it
+			if err != nil && !errors.Is(err, syscall.EIO) && !errors.Is(err, os.ErrClosed) {
+				copyErr = err
+			}
 			zlog.Debug("completed pty output copier")
 		}()
 	}
 
+	var timedOut atomic.Bool
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			if err := killProcessGroup(cmd); err != nil {
+				zlog.Debug("unable to kill timed out command", zap.Stringer("cmd", info), zap.Error(err))
+			}
+		})
+		defer timer.Stop()
+	}
+
 	err = cmd.Wait()
-	return captured.String(), info, err
+	if copyDone != nil {
+		// The end of the command's output might not have been copied yet
+		select {
+		case <-copyDone:
+		case <-time.After(commandWaitDelay):
+			// A process started in the background by the command still holds the PTY
+			ptyFile.Close()
+			<-copyDone
+		}
+
+		if err == nil && copyErr != nil {
+			err = fmt.Errorf("copy command PTY output: %w", copyErr)
+		}
+	}
+
+	if err != nil && timedOut.Load() {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	if err != nil {
+		recordFailedCommandOutput(captured.String())
+	}
+
+	return captured.String(), err
+}
+
+// commandWaitDelay is how long a killed command's output is still read, see [exec.Cmd.WaitDelay].
+const commandWaitDelay = 5 * time.Second
+
+const maxFailedCommandOutputs = 5
+
+// failedCommandOutputs keeps the output of the last commands that failed, they are matched
+// against known issues when the release fails, see [printKnownIssuesDiagnosis].
+var failedCommandOutputs []string
+
+func recordFailedCommandOutput(output string) {
+	failedCommandOutputs = append(failedCommandOutputs, output)
+	if len(failedCommandOutputs) > maxFailedCommandOutputs {
+		failedCommandOutputs = failedCommandOutputs[1:]
+	}
 }
 
 func resultOf(inputs ...string) string {
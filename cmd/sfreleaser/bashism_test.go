@@ -2,8 +2,10 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_newCommandInfo(t *testing.T) {
@@ -47,3 +49,44 @@ func Test_newCommandInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_runCommand_Timeout(t *testing.T) {
+	previous := ptyDisabled
+	ptyDisabled = true
+	t.Cleanup(func() { ptyDisabled = previous })
+
+	_, err := runCommand(newCommandInfo("sleep 5"), true, 50*time.Millisecond)
+	assert.EqualError(t, err, "timed out after 50ms")
+
+	// The background process must be killed too, it holds the output of the command
+	start := time.Now()
+	_, err = runCommand(newCommandInfo(`bash -c 'sleep 5 & wait'`), true, 100*time.Millisecond)
+	assert.EqualError(t, err, "timed out after 100ms")
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	output, err := runCommand(newCommandInfo("echo completed"), true, 5*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "completed\n", output)
+}
+
+func Test_runCommand_PTY(t *testing.T) {
+	previous := ptyDisabled
+	ptyDisabled = false
+	t.Cleanup(func() { ptyDisabled = previous })
+
+	// The output written right before exiting must be captured, even when the command fails
+	for i := 0; i < 20; i++ {
+		output, err := runCommand(newCommandInfo(`bash -c 'echo first; echo "422 Validation Failed: target_commitish"; exit 1'`), true, 0)
+		require.Error(t, err)
+		assert.Equal(t, "first\r\n422 Validation Failed: target_commitish\r\n", output)
+	}
+
+	start := time.Now()
+	_, err := runCommand(newCommandInfo(`bash -c 'sleep 5 & wait'`), true, 100*time.Millisecond)
+	assert.EqualError(t, err, "timed out after 100ms")
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	output, err := runCommand(newCommandInfo("echo completed"), true, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "completed\r\n", output)
+}
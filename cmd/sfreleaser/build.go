@@ -27,6 +27,9 @@ var BuildCmd = Command(nil,
 		language and variant.
 
 		Refer to 'sfreleaser releaser --help' for more information on the available options.
+
+		Use '--output json' to emit the progress of the build as newline-delimited JSON events
+		on standard output, see 'sfreleaser release --help' for the list of events.
 	`),
 	ExamplePrefixed("sfreleaser build", `
 		# Build for the current platform when no argument
@@ -47,6 +50,8 @@ var BuildCmd = Command(nil,
 		flags.StringArray("pre-build-hooks", nil, "Set of pre build hooks to run before run the actual building steps")
 		flags.String("goreleaser-docker-image", "goreleaser/goreleaser-cross:v1.25", "Full Docker image used to run Goreleaser tool (which perform Go builds and GitHub releases (in all languages))")
 
+		flags.String("output", "text", "Output mode, 'text' (human readable) or 'json' (newline-delimited JSON events on standard output)")
+
 		// Flag specific to build
 		flags.Bool("all", false, "Build for all platforms and not your current machine")
 		flags.StringArrayP("platform", "P", nil, "Run only for those platform (repeat --platform <value> for multiple platforms), platform are defined as 'os/arch' (e.g. 'linux/amd64', dash separator also accepted), use 'darwin' to build for macOS and 'windows' for Windows (if activated)")
@@ -57,7 +62,7 @@ var BuildCmd = Command(nil,
 
 		go func() {
 			<-sigs
-			cli.Exit(1)
+			failCommand("interrupted")
 		}()
 
 		if err := build(cmd, args); err != nil {
@@ -70,29 +75,39 @@ var BuildCmd = Command(nil,
 		return nil
 	}),
 	OnCommandError(func(err error) {
-		fmt.Println("The build failed with the following error:")
-		fmt.Println()
-		fmt.Println(err.Error())
-		fmt.Println()
+		failCommand(err.Error())
+	}),
+)
 
+// printBuildFailure explains the build failure `message`, with the known issues matching it
+// or the output of the commands that failed.
+func printBuildFailure(message string) {
+	fmt.Println()
+	fmt.Println("The build failed with the following error:")
+	fmt.Println()
+	fmt.Println(message)
+	fmt.Println()
+
+	if !printKnownIssuesDiagnosis(message + "\n" + strings.Join(failedCommandOutputs, "\n")) {
 		fmt.Println("If the error is not super clear, you can use 'sfreleaser doctor' which")
 		fmt.Println("list common errors and how to fix them.")
-
-		cli.Exit(1)
-	}),
-)
+	}
+}
 
 func build(cmd *cobra.Command, args []string) error {
+	mustSetupOutput(cmd)
+	setupCommandFailure(printBuildFailure)
+
 	global := mustGetGlobal(cmd)
 	build := &BuildModel{Version: ""}
 	if len(args) > 0 {
-		build.Version = args[0]
-		cli.NoError(validVersion(build.Version), "invalid version")
+		cli.NoError(validVersion(args[0], global.TagPrefix), "invalid version")
+		build.Version = global.TrimTagPrefix(args[0])
 	}
 
 	allowDirty := sflags.MustGetBool(cmd, "allow-dirty")
 	goreleaserDockerImage := sflags.MustGetString(cmd, "goreleaser-docker-image")
-	preBuildHooks := sflags.MustGetStringArray(cmd, "pre-build-hooks")
+	preBuildHooks := mustGetHooks(cmd, "pre-build-hooks")
 
 	build.populate(cmd)
 
@@ -100,7 +115,7 @@ func build(cmd *cobra.Command, args []string) error {
 		zap.Inline(global),
 		zap.Bool("allow_dirty", allowDirty),
 		zap.String("goreleaser_docker_image", goreleaserDockerImage),
-		zap.Strings("pre_build_hooks", preBuildHooks),
+		zap.Reflect("pre_build_hooks", preBuildHooks),
 		zap.Reflect("build_model", build),
 	)
 
@@ -113,6 +128,7 @@ func build(cmd *cobra.Command, args []string) error {
 	// For simplicity in the code below
 	version := build.Version
 	fmt.Printf("Building %q ...\n", version)
+	emitEvent(&outputEvent{Type: eventStarted, Repository: global.Owner + "/" + global.Project, Version: version})
 
 	buildDirectory := "build"
 	envFilePath := filepath.Join(buildDirectory, ".env.release")
@@ -127,19 +143,23 @@ func build(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(preBuildHooks) > 0 {
-		fmt.Println()
-		fmt.Printf("Executing %d pre-build hook(s)\n", len(preBuildHooks))
-		executeHooks(preBuildHooks, buildDirectory, global, &ReleaseModel{Version: version})
+		trackStep(string(releaseStepPreBuildHooks), func() {
+			fmt.Println()
+			fmt.Printf("Executing %d pre-build hook(s)\n", len(preBuildHooks))
+			executeHooks(preBuildHooks, buildDirectory, global, &ReleaseModel{Version: version})
+		})
 	}
 
 	if version != "" {
-		fmt.Println()
-		fmt.Println("Creating temporary tag so that goreleaser can work properly")
-		run("git tag", version)
+		trackStep(string(releaseStepTag), func() {
+			fmt.Println()
+			fmt.Println("Creating temporary tag so that goreleaser can work properly")
+			run("git tag", global.Tag(version))
+		})
 
 		cli.ExitHandler(deleteTagExitHandlerID, func(_ int) {
 			zlog.Debug("Deleting local temporary tag")
-			runSilent("git tag -d", version)
+			runSilent("git tag -d", global.Tag(version))
 		})
 	}
 
@@ -151,15 +171,20 @@ func build(cmd *cobra.Command, args []string) error {
 	}
 
 	if global.Language == LanguageRust && global.Variant == VariantSubstreams {
-		fmt.Println()
-		fmt.Println("Building Substreams package (.spkg)")
-		buildSubstreamsPackage(global)
+		trackStep(string(releaseStepSubstreamsPackage), func() {
+			fmt.Println()
+			fmt.Println("Building Substreams package (.spkg)")
+			buildSubstreamsPackage(global)
+		})
 	}
 
-	fmt.Println()
-	fmt.Printf("Building artifacts using image %q\n", goreleaserDockerImage)
-	buildArtifacts(global, build, gitHubRelease)
+	trackStep("build-artifacts", func() {
+		fmt.Println()
+		fmt.Printf("Building artifacts using image %q\n", goreleaserDockerImage)
+		buildArtifacts(global, build, gitHubRelease)
+	})
 
+	emitEvent(&outputEvent{Type: eventCompleted, Version: version})
 	return nil
 }
 
@@ -1,9 +1,9 @@
 package main
 
 import (
-	"bufio"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,7 +14,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"github.com/streamingfast/cli"
 	. "github.com/streamingfast/cli"
 )
 
@@ -25,19 +24,30 @@ var ChangelogExtractSectionCmd = Command(changelogExtractSection,
 		flags.String("start-header-regex", "", "Regex pattern to match section start headers (defaults to '^## .+' or '^## <version>' if version specified)")
 		flags.String("end-header-regex", "^## .+", "Regex pattern to match section end headers")
 		flags.String("github-output", "", "Path to GITHUB_OUTPUT file for GitHub Actions (format: 'path' or 'variable:path', defaults to 'changelog' variable)")
+		flags.String("format", "markdown", "Output format of the extracted section, one of 'markdown' (raw section content), 'json' (structured release with its sections and entries) or 'plain' (one entry per line)")
+		flags.StringArray("section", nil, "Only output the given '### <name>' sub-section(s) of the extracted section (case-insensitive, repeat the flag for multiple values)")
 	}),
 	Description(`
 		Extracts a specific section from a changelog file.
 
 		Arguments:
 		  file     Path to the changelog file (defaults to "CHANGELOG.md") or GitHub URL
-		  version  Version to extract (defaults to first section found)
+		  version  Version to extract, the first section whose header contains it (defaults to
+		           first section found)
 
 		The file argument can be either:
 		- A local file path: "CHANGELOG.md"
 		- A GitHub URL: "github://[token:<token>@]<owner>/<repo>/[blob/]<sha>/<file_path>"
 		  Token is optional for public repositories. The "/blob/" part is optional.
 
+		Output Format:
+		The --format flag controls how the extracted section is printed:
+		- "markdown": The raw Markdown content of the section (default)
+		- "json": The parsed section with its version, date, '### ' sub-sections and their entries
+		- "plain": Each bullet entry on its own line, without the list marker
+
+		Use --section to only keep some '### ' sub-sections, for example '--section=Fixed'.
+
 		GitHub Actions Output:
 		The --github-output flag writes the extracted changelog content to the GitHub Actions
 		output file in the proper format for use in workflows. It supports two formats:
@@ -71,6 +81,9 @@ var ChangelogExtractSectionCmd = Command(changelogExtractSection,
 		# Extract specific version
 		CHANGELOG.md v1.2.3
 
+		# Extract only the 'Fixed' entries of a specific version as JSON
+		--format=json --section=Fixed CHANGELOG.md v2.0.0
+
 		# Extract using custom regex pattern
 		--start-header-regex="## v1\\.2\\..+" CHANGELOG.md
 
@@ -114,6 +127,12 @@ func changelogExtractSection(cmd *cobra.Command, args []string) error {
 	startHeaderRegex, _ := cmd.Flags().GetString("start-header-regex")
 	endHeaderRegex, _ := cmd.Flags().GetString("end-header-regex")
 	githubOutputFile, _ := cmd.Flags().GetString("github-output")
+	format, _ := cmd.Flags().GetString("format")
+	sections, _ := cmd.Flags().GetStringArray("section")
+
+	if format != "markdown" && format != "json" && format != "plain" {
+		return fmt.Errorf("invalid format %q, accepted values are 'markdown', 'json' or 'plain'", format)
+	}
 
 	// Set default start header regex if not provided
 	if startHeaderRegex == "" {
@@ -134,17 +153,13 @@ func changelogExtractSection(cmd *cobra.Command, args []string) error {
 		endHeaderRegex = "^## .+"
 	}
 
-	// Debug output
-	// fmt.Fprintf(os.Stderr, "Debug: file=%q, version=%q, startRegex=%q, endRegex=%q\n",
-	//	changelogFile, targetVersion, startHeaderRegex, endHeaderRegex)
-
 	// Extract section
-	section, err := extractChangelogSection(changelogFile, startHeaderRegex, endHeaderRegex)
+	release, err := extractChangelogRelease(changelogFile, startHeaderRegex, endHeaderRegex)
 	if err != nil {
 		return err
 	}
 
-	if section == "" {
+	if release == nil || release.Body == "" {
 		if targetVersion != "" {
 			fmt.Fprintf(os.Stderr, "No section found for version %q in %s\n", targetVersion, changelogFile)
 		} else {
@@ -153,6 +168,19 @@ func changelogExtractSection(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
+	if len(sections) > 0 {
+		release = release.FilterSections(sections)
+		if len(release.Sections) == 0 {
+			fmt.Fprintf(os.Stderr, "No sub-section %q found in section %q of %s\n", strings.Join(sections, ", "), release.Header, changelogFile)
+			os.Exit(1)
+		}
+	}
+
+	section, err := formatChangelogRelease(release, format)
+	if err != nil {
+		return err
+	}
+
 	// Handle GitHub Actions output format
 	if githubOutputFile != "" {
 		variableName := "changelog" // default variable name
@@ -176,88 +204,63 @@ func changelogExtractSection(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// extractChangelogSection extracts a section from a changelog using custom regex patterns
-func extractChangelogSection(changelogFile, startHeaderRegex, endHeaderRegex string) (string, error) {
+// extractChangelogRelease extracts a section from a changelog using custom regex patterns,
+// nil if no section matches
+func extractChangelogRelease(changelogFile, startHeaderRegex, endHeaderRegex string) (*ChangelogRelease, error) {
+	startRegex, err := regexp.Compile(startHeaderRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start header regex %q: %w", startHeaderRegex, err)
+	}
+
+	endRegex, err := regexp.Compile(endHeaderRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end header regex %q: %w", endHeaderRegex, err)
+	}
+
+	var changelog *Changelog
+
 	// Check if it's a GitHub URL
 	if strings.HasPrefix(changelogFile, "github://") {
 		ghURL, err := parseGitHubURL(changelogFile)
 		if err != nil {
-			return "", fmt.Errorf("invalid GitHub URL %q: %w", changelogFile, err)
+			return nil, fmt.Errorf("invalid GitHub URL %q: %w", changelogFile, err)
 		}
 
-		startRegex, err := regexp.Compile(startHeaderRegex)
+		changelog, err = readChangelogFromGitHub(ghURL)
 		if err != nil {
-			return "", fmt.Errorf("invalid start header regex %q: %w", startHeaderRegex, err)
+			return nil, err
 		}
-
-		endRegex, err := regexp.Compile(endHeaderRegex)
+	} else {
+		changelog, err = parseChangelogFile(changelogFile)
 		if err != nil {
-			return "", fmt.Errorf("invalid end header regex %q: %w", endHeaderRegex, err)
+			return nil, err
 		}
-
-		return readReleaseNotesFromGitHub(ghURL, startRegex, endRegex)
-	}
-
-	// Handle local file
-	if !cli.FileExists(changelogFile) {
-		return "", fmt.Errorf("changelog file %q does not exist", changelogFile)
 	}
 
-	startRegex, err := regexp.Compile(startHeaderRegex)
-	if err != nil {
-		return "", fmt.Errorf("invalid start header regex %q: %w", startHeaderRegex, err)
-	}
-
-	endRegex, err := regexp.Compile(endHeaderRegex)
-	if err != nil {
-		return "", fmt.Errorf("invalid end header regex %q: %w", endHeaderRegex, err)
-	}
-
-	return readReleaseNotesWithRegex(changelogFile, startRegex, endRegex)
+	return changelog.Extract(startRegex, endRegex), nil
 }
 
-// readReleaseNotesWithRegex is like readReleaseNotes but uses custom regex patterns
-func readReleaseNotesWithRegex(changelogFile string, startRegex, endRegex *regexp.Regexp) (string, error) {
-	if !cli.FileExists(changelogFile) {
-		return "", fmt.Errorf("changelog file %q does not exist", changelogFile)
-	}
-
-	file, err := os.Open(changelogFile)
-	if err != nil {
-		return "", fmt.Errorf("unable to open changelog %q: %w", changelogFile, err)
-	}
-	defer file.Close()
-
-	foundFirstHeader := false
-	var releaseNotes []string
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		if !foundFirstHeader && startRegex.MatchString(line) {
-			foundFirstHeader = true
-			continue
+func formatChangelogRelease(release *ChangelogRelease, format string) (string, error) {
+	switch format {
+	case "json":
+		content, err := json.MarshalIndent(release, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal section: %w", err)
 		}
 
-		if foundFirstHeader {
-			if endRegex.MatchString(line) {
-				break
-			}
+		return string(content) + "\n", nil
 
-			releaseNotes = append(releaseNotes, line)
+	case "plain":
+		entries := release.AllEntries()
+		if len(entries) == 0 {
+			return "", nil
 		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("error reading changelog: %w", err)
-	}
+		return strings.Join(entries, "\n") + "\n", nil
 
-	if !foundFirstHeader {
-		return "", nil
+	default:
+		return release.Body, nil
 	}
-
-	return trimBlankLines(strings.Join(releaseNotes, "\n")), nil
 }
 
 // GitHubURL represents a parsed GitHub URL
@@ -373,44 +376,15 @@ func downloadFromGitHub(ghURL *GitHubURL) (io.ReadCloser, error) {
 	return resp.Body, nil
 }
 
-// readReleaseNotesFromGitHub reads and parses changelog from GitHub using custom regex patterns
-func readReleaseNotesFromGitHub(ghURL *GitHubURL, startRegex, endRegex *regexp.Regexp) (string, error) {
+// readChangelogFromGitHub downloads and parses a changelog from GitHub
+func readChangelogFromGitHub(ghURL *GitHubURL) (*Changelog, error) {
 	reader, err := downloadFromGitHub(ghURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to download file from GitHub: %w", err)
+		return nil, fmt.Errorf("failed to download file from GitHub: %w", err)
 	}
 	defer reader.Close()
 
-	foundFirstHeader := false
-	var releaseNotes []string
-
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		if !foundFirstHeader && startRegex.MatchString(line) {
-			foundFirstHeader = true
-			continue
-		}
-
-		if foundFirstHeader {
-			if endRegex.MatchString(line) {
-				break
-			}
-
-			releaseNotes = append(releaseNotes, line)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("error reading changelog: %w", err)
-	}
-
-	if !foundFirstHeader {
-		return "", nil
-	}
-
-	return trimBlankLines(strings.Join(releaseNotes, "\n")), nil
+	return parseChangelog(reader)
 }
 
 // writeGitHubOutput writes a variable to the GitHub Actions output file using the proper format
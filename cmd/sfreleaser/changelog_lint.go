@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	versioning "github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+)
+
+var ChangelogLintCmd = Command(changelogLint,
+	"lint [<file>]",
+	"Validate the structure of a changelog file",
+	Flags(func(flags *pflag.FlagSet) {
+		flags.StringArray("extra-sections", nil, "Additional '###' section names accepted on top of the Keep a Changelog ones (repeat the flag for multiple values)")
+	}),
+	Description(`
+		Validates that the changelog file (defaults to "CHANGELOG.md") follows the Keep a Changelog
+		format (https://keepachangelog.com/en/1.0.0/), the same format generated by 'sfreleaser init'.
+
+		The following rules are checked:
+		- The file starts with a '# <title>' header
+		- Release headers are of the form '## <version>', '## [<version>]', optionally followed
+		  by ' - YYYY-MM-DD', where <version> is a valid semantic version (a 'v' prefix is accepted)
+		- A single '## Unreleased' (or '## Next') section is allowed and must be the first one
+		- Versions are strictly descending and there is no duplicated version
+		- Sub-sections ('### <name>') are one of Added, Changed, Deprecated, Removed, Fixed, Security,
+		  Breaking Changes (or one of '--extra-sections'), 'Deprecation' is accepted for Deprecated
+
+		Each problem found is printed with its line number. The command exits with a non-zero
+		exit code if at least one error is found, making it suitable for CI usage.
+	`),
+	ExamplePrefixed("sfreleaser changelog lint", `
+		# Lint default CHANGELOG.md
+
+		# Lint a specific file accepting an extra 'Performance' section
+		--extra-sections=Performance docs/CHANGELOG.md
+	`),
+)
+
+func changelogLint(cmd *cobra.Command, args []string) error {
+	changelogFile := "CHANGELOG.md"
+	if len(args) > 0 {
+		changelogFile = args[0]
+	}
+
+	extraSections := sflags.MustGetStringArray(cmd, "extra-sections")
+
+	file, err := os.Open(changelogFile)
+	if err != nil {
+		return fmt.Errorf("unable to open changelog %q: %w", changelogFile, err)
+	}
+	defer file.Close()
+
+	diagnostics := lintChangelog(file, extraSections)
+
+	errorCount := 0
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s:%s\n", changelogFile, diagnostic)
+
+		if diagnostic.Severity == changelogSeverityError {
+			errorCount++
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("changelog %q has %d error(s)", changelogFile, errorCount)
+	}
+
+	fmt.Printf("Changelog %q is valid\n", changelogFile)
+	return nil
+}
+
+type changelogSeverity string
+
+const (
+	changelogSeverityError   changelogSeverity = "error"
+	changelogSeverityWarning changelogSeverity = "warning"
+)
+
+type changelogDiagnostic struct {
+	Line     int
+	Severity changelogSeverity
+	Message  string
+}
+
+func (d *changelogDiagnostic) String() string {
+	return fmt.Sprintf("%d: %s: %s", d.Line, d.Severity, d.Message)
+}
+
+// keepAChangelogSections are the sub-sections defined by Keep a Changelog, plus 'Breaking Changes'
+// which is widely used in our projects.
+var keepAChangelogSections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security", "Breaking Changes"}
+
+// changelogSectionAliases are spellings of Keep a Changelog sub-sections found in already
+// released sections of our changelogs, keyed by their lower cased form.
+var changelogSectionAliases = map[string]string{
+	"deprecation": "Deprecated",
+}
+
+var (
+	changelogReleaseHeaderRegex = regexp.MustCompile(`^##\s+(?:\[([^\]]+)\]|(\S+))(?:\s+-\s+(.+?))?\s*$`)
+	changelogSemverRegex        = regexp.MustCompile(`^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+)
+
+func isUnreleasedHeaderName(name string) bool {
+	normalized := strings.ToLower(name)
+	return normalized == "unreleased" || normalized == "next"
+}
+
+func lintChangelog(reader io.Reader, extraSections []string) (diagnostics []*changelogDiagnostic) {
+	report := func(line int, severity changelogSeverity, format string, args ...any) {
+		diagnostics = append(diagnostics, &changelogDiagnostic{line, severity, fmt.Sprintf(format, args...)})
+	}
+
+	allowedSections := append(slices.Clone(keepAChangelogSections), extraSections...)
+
+	type releaseSection struct {
+		line       int
+		name       string
+		version    *versioning.Version
+		hasContent bool
+	}
+
+	var (
+		lineNumber   int
+		inCodeBlock  bool
+		foundTitle   bool
+		releases     []*releaseSection
+		seenVersions = map[string]int{}
+		lastVersion  *releaseSection
+	)
+
+	checkReleaseContent := func() {
+		if len(releases) > 0 && !releases[len(releases)-1].hasContent {
+			current := releases[len(releases)-1]
+			report(current.line, changelogSeverityWarning, "section %q has no content", current.name)
+		}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+		}
+
+		if inCodeBlock || trimmed == "" {
+			if inCodeBlock && len(releases) > 0 {
+				releases[len(releases)-1].hasContent = true
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "# "):
+			if !foundTitle && len(releases) == 0 {
+				foundTitle = true
+			}
+
+		case strings.HasPrefix(line, "## "):
+			checkReleaseContent()
+
+			if !foundTitle && len(releases) == 0 {
+				report(lineNumber, changelogSeverityError, "missing '# Changelog' title before the first release section")
+				foundTitle = true
+			}
+
+			groups := changelogReleaseHeaderRegex.FindStringSubmatch(line)
+			if groups == nil {
+				report(lineNumber, changelogSeverityError, "invalid release header %q, expected '## <version>' or '## [<version>] - YYYY-MM-DD'", line)
+				releases = append(releases, &releaseSection{line: lineNumber, name: trimmed, hasContent: true})
+				continue
+			}
+
+			name := groups[1] + groups[2]
+			date := groups[3]
+			section := &releaseSection{line: lineNumber, name: name}
+
+			if isUnreleasedHeaderName(name) {
+				// An empty Unreleased section is perfectly fine
+				section.hasContent = true
+
+				if len(releases) > 0 {
+					report(lineNumber, changelogSeverityError, "%q section must be the first release section", name)
+				}
+
+				if date != "" {
+					report(lineNumber, changelogSeverityError, "%q section must not have a date", name)
+				}
+
+				releases = append(releases, section)
+				continue
+			}
+
+			releases = append(releases, section)
+
+			if !changelogSemverRegex.MatchString(name) {
+				report(lineNumber, changelogSeverityError, "version %q is not a valid semantic version (expected '[v]<major>.<minor>.<patch>[-<pre-release>]')", name)
+				continue
+			}
+
+			if date != "" {
+				if _, err := time.Parse("2006-01-02", date); err != nil {
+					report(lineNumber, changelogSeverityError, "release date %q of version %q is invalid, expected format YYYY-MM-DD", date, name)
+				}
+			}
+
+			// Regex validation above ensures the version is valid
+			section.version, _ = versioning.NewVersion(name)
+
+			normalized := section.version.String()
+			if previousLine, found := seenVersions[normalized]; found {
+				report(lineNumber, changelogSeverityError, "version %q is duplicated, first defined at line %d", name, previousLine)
+				continue
+			}
+			seenVersions[normalized] = lineNumber
+
+			if lastVersion != nil && !section.version.LessThan(lastVersion.version) {
+				report(lineNumber, changelogSeverityError, "version %q must be lower than previous version %q (line %d), versions must be in strictly descending order", name, lastVersion.name, lastVersion.line)
+			}
+
+			lastVersion = section
+
+		case strings.HasPrefix(line, "### "):
+			name := strings.TrimSpace(strings.TrimPrefix(line, "### "))
+
+			if len(releases) == 0 {
+				report(lineNumber, changelogSeverityError, "section %q is not part of any release section", name)
+				continue
+			}
+
+			releases[len(releases)-1].hasContent = true
+
+			if alias, found := changelogSectionAliases[strings.ToLower(name)]; found {
+				name = alias
+			}
+
+			if !slices.ContainsFunc(allowedSections, func(allowed string) bool { return strings.EqualFold(allowed, name) }) {
+				report(lineNumber, changelogSeverityError, "unknown section %q, expected one of %s", name, strings.Join(allowedSections, ", "))
+			}
+
+		default:
+			if len(releases) > 0 {
+				releases[len(releases)-1].hasContent = true
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		report(lineNumber, changelogSeverityError, "unable to read changelog: %s", err)
+		return
+	}
+
+	checkReleaseContent()
+
+	if !foundTitle {
+		report(1, changelogSeverityError, "missing '# Changelog' title")
+	}
+
+	return
+}
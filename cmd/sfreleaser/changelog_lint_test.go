@@ -0,0 +1,181 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lintChangelog(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		extraSections []string
+		want          []string
+	}{
+		{
+			"valid",
+			`
+			# Changelog
+
+			## Unreleased
+
+			## [v1.2.0] - 2024-01-15
+
+			### Added
+
+			- Feature
+
+			## 1.1.0
+
+			- Change
+
+			## v1.0.0-rc.1
+
+			- Pre-release
+			`,
+			nil,
+			nil,
+		},
+		{
+			"missing title",
+			`
+			## v1.0.0
+
+			- Change
+			`,
+			nil,
+			[]string{"1: error: missing '# Changelog' title before the first release section"},
+		},
+		{
+			"unreleased not first",
+			`
+			# Changelog
+
+			## v1.0.0
+
+			- Change
+
+			## Unreleased
+			`,
+			nil,
+			[]string{`7: error: "Unreleased" section must be the first release section`},
+		},
+		{
+			"invalid version and date",
+			`
+			# Changelog
+
+			## v1.0
+
+			- Change
+
+			## [v0.9.0] - 2024-13-01
+
+			- Change
+			`,
+			nil,
+			[]string{
+				`3: error: version "v1.0" is not a valid semantic version (expected '[v]<major>.<minor>.<patch>[-<pre-release>]')`,
+				`7: error: release date "2024-13-01" of version "v0.9.0" is invalid, expected format YYYY-MM-DD`,
+			},
+		},
+		{
+			"not descending and duplicated",
+			`
+			# Changelog
+
+			## v1.0.0
+
+			- Change
+
+			## v1.1.0
+
+			- Change
+
+			## 1.0.0
+
+			- Change
+			`,
+			nil,
+			[]string{
+				`7: error: version "v1.1.0" must be lower than previous version "v1.0.0" (line 3), versions must be in strictly descending order`,
+				`11: error: version "1.0.0" is duplicated, first defined at line 3`,
+			},
+		},
+		{
+			"section alias",
+			`
+			# Changelog
+
+			## v1.0.0
+
+			### Deprecation
+
+			- Old flag
+			`,
+			nil,
+			nil,
+		},
+		{
+			"unknown section",
+			`
+			# Changelog
+
+			## v1.0.0
+
+			### Performance
+
+			- Faster
+
+			### Highlights
+
+			- Nice
+			`,
+			[]string{"Performance"},
+			[]string{`9: error: unknown section "Highlights", expected one of Added, Changed, Deprecated, Removed, Fixed, Security, Breaking Changes, Performance`},
+		},
+		{
+			"empty release and code block ignored",
+			"# Changelog\n\n## v1.1.0\n\n## v1.0.0\n\n```\n## not a header\n```\n",
+			nil,
+			[]string{`3: warning: section "v1.1.0" has no content`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, diagnostic := range lintChangelog(strings.NewReader(dedent(tt.content)), tt.extraSections) {
+				got = append(got, diagnostic.String())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_lintChangelog_Files(t *testing.T) {
+	tests := []struct {
+		file          string
+		extraSections []string
+	}{
+		{"../../CHANGELOG.md", nil},
+		{"testdata/changelog/sample.md", nil},
+		{"testdata/changelog/no-unreleased.md", nil},
+		{"testdata/changelog/structured.md", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			content, err := os.Open(tt.file)
+			require.NoError(t, err)
+			defer content.Close()
+
+			assert.Empty(t, lintChangelog(content, tt.extraSections))
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/streamingfast/cli"
+)
+
+// Changelog is the parsed form of a changelog file. Releases are delimited by '## ' headers
+// and each of them is further split into '### ' sections and bullet entries.
+type Changelog struct {
+	Title    string              `json:"title,omitempty"`
+	Releases []*ChangelogRelease `json:"releases"`
+
+	// lines are the raw lines of the changelog, kept to extract arbitrary regions of it
+	lines []string
+}
+
+type ChangelogRelease struct {
+	Header string `json:"header"`
+	// Line is the 1-based line number of the release's header in the changelog
+	Line       int    `json:"line"`
+	Version    string `json:"version,omitempty"`
+	Unreleased bool   `json:"unreleased,omitempty"`
+	Date       string `json:"date,omitempty"`
+	// Entries are the bullet entries found before the first '### ' section, if any
+	Entries  []string            `json:"entries,omitempty"`
+	Sections []*ChangelogSection `json:"sections,omitempty"`
+	// Body is the raw Markdown content of the release, without its header
+	Body string `json:"body"`
+}
+
+type ChangelogSection struct {
+	Name    string   `json:"name"`
+	Entries []string `json:"entries"`
+	// Body is the raw Markdown content of the section, without its header
+	Body string `json:"body"`
+}
+
+var changelogEntryRegex = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+
+func parseChangelogFile(changelogFile string) (*Changelog, error) {
+	if !cli.FileExists(changelogFile) {
+		return nil, fmt.Errorf("changelog file %q does not exist", changelogFile)
+	}
+
+	file, err := os.Open(changelogFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open changelog %q: %w", changelogFile, err)
+	}
+	defer file.Close()
+
+	return parseChangelog(file)
+}
+
+func parseChangelog(reader io.Reader) (*Changelog, error) {
+	changelog := &Changelog{}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		changelog.lines = append(changelog.lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading changelog: %w", err)
+	}
+
+	for _, line := range changelog.lines {
+		if strings.HasPrefix(line, "# ") {
+			changelog.Title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			break
+		}
+
+		if headerRegex.MatchString(line) {
+			break
+		}
+	}
+
+	changelog.walk(headerRegex, headerRegex, func(release *ChangelogRelease) bool {
+		changelog.Releases = append(changelog.Releases, release)
+		return true
+	})
+
+	return changelog, nil
+}
+
+// ReleaseNotes returns the notes of `version`, the body of the first release of the changelog
+// matching, in order:
+//   - `version` itself (e.g. once 'Unreleased' was promoted), the 'v' prefix being optional
+//   - the base version of a pre-release `version` (e.g. '## v1.3.0' for 'v1.3.0-rc.1')
+//   - the first release, usually 'Unreleased', as well as when `version` is empty
+func (c *Changelog) ReleaseNotes(version string) string {
+	if len(c.Releases) == 0 {
+		return ""
+	}
+
+	if version != "" {
+		normalized := strings.TrimPrefix(version, "v")
+		base, _, _ := strings.Cut(strings.SplitN(normalized, "+", 2)[0], "-")
+
+		for _, candidate := range []string{normalized, base} {
+			for _, release := range c.Releases {
+				if release.Version != "" && strings.TrimPrefix(release.Version, "v") == candidate {
+					return release.Body
+				}
+			}
+		}
+	}
+
+	return c.Releases[0].Body
+}
+
+// Extract returns the region starting after the first line matching `startRegex` up to (but
+// excluding) the next line matching `endRegex` as a release, nil if `startRegex` never matches.
+func (c *Changelog) Extract(startRegex, endRegex *regexp.Regexp) (release *ChangelogRelease) {
+	c.walk(startRegex, endRegex, func(candidate *ChangelogRelease) bool {
+		release = candidate
+		return false
+	})
+
+	return
+}
+
+// walk calls `onRelease` for each region of the changelog starting at a line matching
+// `startRegex` and ending before the next line matching `endRegex`, until `onRelease` returns
+// false. Lines within fenced code blocks never delimit a region.
+func (c *Changelog) walk(startRegex, endRegex *regexp.Regexp, onRelease func(release *ChangelogRelease) bool) {
+	var (
+		header      string
+		headerIndex = -1
+		body        []string
+		inCodeBlock bool
+	)
+
+	flush := func() bool {
+		if headerIndex == -1 {
+			return true
+		}
+
+		release := newChangelogRelease(header, headerIndex+1, body)
+		headerIndex, body = -1, nil
+
+		return onRelease(release)
+	}
+
+	for i, line := range c.lines {
+		isFence := strings.HasPrefix(strings.TrimSpace(line), "```")
+
+		if !inCodeBlock && !isFence {
+			if headerIndex != -1 && endRegex.MatchString(line) {
+				if !flush() {
+					return
+				}
+			}
+
+			if headerIndex == -1 && startRegex.MatchString(line) {
+				header, headerIndex = line, i
+				continue
+			}
+		}
+
+		if isFence {
+			inCodeBlock = !inCodeBlock
+		}
+
+		if headerIndex != -1 {
+			body = append(body, line)
+		}
+	}
+
+	flush()
+}
+
+func newChangelogRelease(header string, line int, body []string) *ChangelogRelease {
+	release := &ChangelogRelease{
+		Header:     header,
+		Line:       line,
+		Version:    extractVersionFromHeader(header),
+		Unreleased: isUnreleasedHeader(header),
+		Body:       trimBlankLines(strings.Join(body, "\n")),
+	}
+
+	if groups := changelogReleaseHeaderRegex.FindStringSubmatch(header); groups != nil {
+		release.Date = groups[3]
+	}
+
+	var (
+		section      *ChangelogSection
+		sectionLines []string
+		entry        []string
+		inCodeBlock  bool
+	)
+
+	flushEntry := func() {
+		if len(entry) == 0 {
+			return
+		}
+
+		if section != nil {
+			section.Entries = append(section.Entries, strings.Join(entry, " "))
+		} else {
+			release.Entries = append(release.Entries, strings.Join(entry, " "))
+		}
+
+		entry = nil
+	}
+
+	flushSection := func() {
+		flushEntry()
+
+		if section != nil {
+			section.Body = trimBlankLines(strings.Join(sectionLines, "\n"))
+			release.Sections = append(release.Sections, section)
+		}
+	}
+
+	for _, line := range body {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+		}
+
+		if !inCodeBlock && strings.HasPrefix(line, "### ") {
+			flushSection()
+
+			section = &ChangelogSection{Name: strings.TrimSpace(strings.TrimPrefix(line, "### "))}
+			sectionLines = nil
+			continue
+		}
+
+		if section != nil {
+			sectionLines = append(sectionLines, line)
+		}
+
+		switch {
+		case inCodeBlock || trimmed == "":
+			flushEntry()
+
+		case changelogEntryRegex.MatchString(line):
+			flushEntry()
+			entry = []string{changelogEntryRegex.FindStringSubmatch(line)[1]}
+
+		case len(entry) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			// Continuation of the current entry (wrapped text or nested list)
+			entry = append(entry, trimmed)
+
+		default:
+			flushEntry()
+		}
+	}
+
+	flushSection()
+
+	return release
+}
+
+// FilterSections returns a copy of the release keeping only the '### ' sections whose name
+// is one of `names` (case-insensitive), the body is re-rendered from the kept sections.
+func (r *ChangelogRelease) FilterSections(names []string) *ChangelogRelease {
+	filtered := *r
+	filtered.Entries = nil
+	filtered.Sections = nil
+
+	var bodies []string
+	for _, section := range r.Sections {
+		for _, name := range names {
+			if strings.EqualFold(section.Name, name) {
+				filtered.Sections = append(filtered.Sections, section)
+				bodies = append(bodies, "### "+section.Name+"\n"+section.Body)
+				break
+			}
+		}
+	}
+
+	filtered.Body = strings.Join(bodies, "\n\n")
+	return &filtered
+}
+
+// AllEntries returns the entries of the release followed by the entries of each section.
+func (r *ChangelogRelease) AllEntries() (out []string) {
+	out = append(out, r.Entries...)
+	for _, section := range r.Sections {
+		out = append(out, section.Entries...)
+	}
+
+	return
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseChangelogFile(t *testing.T) {
+	changelog, err := parseChangelogFile("testdata/changelog/structured.md")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Project Changelog", changelog.Title)
+	require.Len(t, changelog.Releases, 2)
+
+	release := changelog.Releases[0]
+	assert.Equal(t, "## [v2.0.0] - 2023-09-01", release.Header)
+	assert.Equal(t, 3, release.Line)
+	assert.Equal(t, "v2.0.0", release.Version)
+	assert.Equal(t, "2023-09-01", release.Date)
+	assert.False(t, release.Unreleased)
+	assert.Nil(t, release.Entries)
+	assert.Equal(t, []*ChangelogSection{
+		{Name: "Added", Entries: []string{"New authentication system", "Support for multiple databases", "Advanced logging capabilities"}, Body: "- New authentication system\n- Support for multiple databases\n- Advanced logging capabilities"},
+		{Name: "Changed", Entries: []string{"Refactored core API", "Updated configuration format"}, Body: "- Refactored core API\n- Updated configuration format"},
+		{Name: "Fixed", Entries: []string{"Memory leak in connection pool", "Race condition in cache"}, Body: "- Memory leak in connection pool\n- Race condition in cache"},
+	}, release.Sections)
+
+	assert.Equal(t, "v1.5.0", changelog.Releases[1].Version)
+	assert.Equal(t, 18, changelog.Releases[1].Line)
+}
+
+func Test_parseChangelog_Entries(t *testing.T) {
+	changelog, err := parseChangelog(strings.NewReader(dedent(`
+		# Changelog
+
+		## Unreleased
+
+		## v1.0.0
+
+		Some introduction paragraph.
+
+		- First entry
+		  wrapped on two lines
+		* Second entry
+		  - With a nested item
+
+		- Third entry
+
+		` + "```" + `
+		## Not a header
+		- Not an entry
+		` + "```" + `
+	`)))
+	require.NoError(t, err)
+
+	require.Len(t, changelog.Releases, 2)
+	assert.True(t, changelog.Releases[0].Unreleased)
+	assert.Equal(t, "", changelog.Releases[0].Body)
+
+	assert.Equal(t, []string{
+		"First entry wrapped on two lines",
+		"Second entry - With a nested item",
+		"Third entry",
+	}, changelog.Releases[1].Entries)
+
+	assert.Equal(t, changelog.Releases[1].Body, changelog.ReleaseNotes("v1.0.0"))
+	assert.Equal(t, "", changelog.ReleaseNotes("v1.1.0"), "the notes of the previous version must not be used for the next one")
+}
+
+func Test_Changelog_ReleaseNotes(t *testing.T) {
+	changelog, err := parseChangelog(strings.NewReader(dedent(`
+		# Changelog
+
+		## Unreleased
+
+		- Unreleased change
+
+		## v1.1.0
+
+		- Promoted change
+
+		## v1.0.0
+
+		- Previous change
+	`)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "- Promoted change", changelog.ReleaseNotes("v1.1.0"))
+	assert.Equal(t, "- Promoted change", changelog.ReleaseNotes("1.1.0"))
+	assert.Equal(t, "- Unreleased change", changelog.ReleaseNotes("v1.2.0"))
+	assert.Equal(t, "- Unreleased change", changelog.ReleaseNotes(""))
+	assert.Equal(t, "- Promoted change", changelog.ReleaseNotes("v1.1.0-rc.1"), "base version of the pre-release")
+	assert.Equal(t, "- Unreleased change", changelog.ReleaseNotes("v1.2.0-rc.1"), "no base version release")
+
+	changelog.Releases = changelog.Releases[1:]
+	assert.Equal(t, "- Promoted change", changelog.ReleaseNotes("v1.2.0"), "no 'Unreleased' nor 'v1.2.0' release, first release")
+	assert.Equal(t, "- Promoted change", changelog.ReleaseNotes("v1.2.0-rc.2"), "no 'Unreleased' nor 'v1.2.0' release, first release")
+	assert.Equal(t, "- Previous change", changelog.ReleaseNotes("v1.0.0-rc.1+build.5"))
+}
+
+func Test_formatChangelogRelease(t *testing.T) {
+	changelog, err := parseChangelogFile("testdata/changelog/structured.md")
+	require.NoError(t, err)
+
+	release := changelog.Extract(regexp.MustCompile(`^## .*v2\.0\.0.*`), regexp.MustCompile(`^## .+`))
+	require.NotNil(t, release)
+
+	fixed := release.FilterSections([]string{"fixed"})
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"markdown", "### Fixed\n- Memory leak in connection pool\n- Race condition in cache"},
+		{"plain", "Memory leak in connection pool\nRace condition in cache\n"},
+		{"json", dedent(`
+			{
+			  "header": "## [v2.0.0] - 2023-09-01",
+			  "line": 3,
+			  "version": "v2.0.0",
+			  "date": "2023-09-01",
+			  "sections": [
+			    {
+			      "name": "Fixed",
+			      "entries": [
+			        "Memory leak in connection pool",
+			        "Race condition in cache"
+			      ],
+			      "body": "- Memory leak in connection pool\n- Race condition in cache"
+			    }
+			  ],
+			  "body": "### Fixed\n- Memory leak in connection pool\n- Race condition in cache"
+			}
+		`) + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := formatChangelogRelease(fixed, tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
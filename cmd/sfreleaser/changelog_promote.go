@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/streamingfast/cli"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+)
+
+var ChangelogPromoteCmd = Command(changelogPromote,
+	"promote <version> [<file>]",
+	"Promote the 'Unreleased' section of a changelog file to a dated version section",
+	RangeArgs(1, 2),
+	Flags(func(flags *pflag.FlagSet) {
+		flags.String("date", "", "Release date to use in the promoted header, in YYYY-MM-DD format (defaults to today)")
+		flags.Bool("no-compare-links", false, "Do not update the '[Unreleased]: .../compare/...' link reference (and add the new version one) at the bottom of the file")
+	}),
+	Description(`
+		Promotes the '## Unreleased' (or '## Next') section of the changelog file (defaults to
+		"CHANGELOG.md") to '## <version> - YYYY-MM-DD' and adds a fresh empty '## Unreleased'
+		section above it.
+
+		If the file ends with Keep a Changelog compare links like:
+
+		  [Unreleased]: https://github.com/owner/project/compare/v1.0.0...HEAD
+
+		the Unreleased link is updated to compare from <version> and a link for <version> is added,
+		use '--no-compare-links' to leave them untouched.
+
+		The command fails if there is no 'Unreleased' section, if it's empty or if <version> is
+		already present in the changelog.
+	`),
+	ExamplePrefixed("sfreleaser changelog promote", `
+		# Promote 'Unreleased' section of CHANGELOG.md to v1.2.3 dated today
+		v1.2.3
+
+		# Promote with an explicit date in another file
+		--date=2024-01-15 v1.2.3 docs/CHANGELOG.md
+	`),
+)
+
+func changelogPromote(cmd *cobra.Command, args []string) error {
+	tagPrefix := sflags.MustGetString(cmd, "tag-prefix")
+	if err := validVersion(args[0], tagPrefix); err != nil {
+		return err
+	}
+
+	version := strings.TrimPrefix(args[0], tagPrefix)
+
+	changelogFile := "CHANGELOG.md"
+	if len(args) > 1 {
+		changelogFile = args[1]
+	}
+
+	date := sflags.MustGetString(cmd, "date")
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	if !cli.FileExists(changelogFile) {
+		return fmt.Errorf("changelog file %q does not exist", changelogFile)
+	}
+
+	promoted, err := promoteChangelog(cli.ReadFile(changelogFile), version, date, !sflags.MustGetBool(cmd, "no-compare-links"))
+	if err != nil {
+		return fmt.Errorf("promote changelog %q: %w", changelogFile, err)
+	}
+
+	cli.WriteFile(changelogFile, "%s", promoted)
+
+	fmt.Printf("Promoted 'Unreleased' section of %q to %q\n", changelogFile, version)
+	return nil
+}
+
+var changelogUnreleasedLinkRegex = regexp.MustCompile(`(?i)^\[(unreleased|next)\]:\s*(\S+)/compare/(\S+)\.\.\.(\S+)\s*$`)
+
+// promoteChangelog turns the 'Unreleased' section of the changelog `content` into a section for
+// `version` released at `date` and adds a new empty 'Unreleased' section on top of it. When
+// `updateCompareLinks` is true, the Keep a Changelog compare link of 'Unreleased' (if present)
+// is updated and a link for `version` is added right after it.
+func promoteChangelog(content string, version string, date string, updateCompareLinks bool) (string, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return "", fmt.Errorf("invalid date %q, expected format YYYY-MM-DD", date)
+	}
+
+	lines := strings.Split(content, "\n")
+
+	section, found := findUnreleasedChangelogSection(lines)
+	if !found {
+		return "", fmt.Errorf("no 'Unreleased' section found, it must be the first '## ' section")
+	}
+
+	if !section.hasContent {
+		return "", fmt.Errorf("'Unreleased' section at line %d is empty, nothing to promote", section.start+1)
+	}
+
+	if line, found := findChangelogVersionHeader(lines, version); found {
+		return "", fmt.Errorf("version %q is already present at line %d", version, line+1)
+	}
+
+	versionLabel := version
+	if section.bracketed {
+		versionLabel = "[" + version + "]"
+	}
+
+	var out []string
+	out = append(out, lines[:section.start]...)
+	out = append(out, lines[section.start], "")
+	out = append(out, fmt.Sprintf("## %s - %s", versionLabel, date))
+	out = append(out, lines[section.start+1:]...)
+
+	if updateCompareLinks {
+		for i, line := range out {
+			groups := changelogUnreleasedLinkRegex.FindStringSubmatch(line)
+			if groups == nil {
+				continue
+			}
+
+			label, baseURL, previous, head := groups[1], groups[2], groups[3], groups[4]
+
+			links := []string{
+				fmt.Sprintf("[%s]: %s/compare/%s...%s", label, baseURL, version, head),
+				fmt.Sprintf("[%s]: %s/compare/%s...%s", version, baseURL, previous, version),
+			}
+
+			out = append(out[:i], append(links, out[i+1:]...)...)
+			break
+		}
+	}
+
+	return strings.Join(out, "\n"), nil
+}
+
+type unreleasedChangelogSection struct {
+	// start is the line index of the section's header
+	start      int
+	bracketed  bool
+	hasContent bool
+}
+
+// findUnreleasedChangelogSection returns the 'Unreleased' section if it's the first '## ' section
+// of the changelog `lines`.
+func findUnreleasedChangelogSection(lines []string) (section *unreleasedChangelogSection, found bool) {
+	inCodeBlock := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+		}
+
+		if inCodeBlock {
+			if section != nil {
+				section.hasContent = true
+			}
+
+			continue
+		}
+
+		if section == nil {
+			if !strings.HasPrefix(line, "## ") {
+				continue
+			}
+
+			groups := changelogReleaseHeaderRegex.FindStringSubmatch(line)
+			if groups == nil || !isUnreleasedHeaderName(groups[1]+groups[2]) {
+				return nil, false
+			}
+
+			section = &unreleasedChangelogSection{start: i, bracketed: groups[1] != ""}
+			continue
+		}
+
+		if strings.HasPrefix(line, "## ") || changelogUnreleasedLinkRegex.MatchString(line) {
+			break
+		}
+
+		if strings.TrimSpace(line) != "" {
+			section.hasContent = true
+		}
+	}
+
+	return section, section != nil
+}
+
+func findChangelogVersionHeader(lines []string, version string) (line int, found bool) {
+	normalized := strings.TrimPrefix(version, "v")
+
+	for i, line := range lines {
+		groups := changelogReleaseHeaderRegex.FindStringSubmatch(line)
+		if groups == nil {
+			continue
+		}
+
+		if strings.TrimPrefix(groups[1]+groups[2], "v") == normalized {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// changelogHasUnreleasedChanges returns true if the changelog file starts with a non-empty
+// 'Unreleased' section.
+func changelogHasUnreleasedChanges(changelogFile string) bool {
+	if !cli.FileExists(changelogFile) {
+		return false
+	}
+
+	section, found := findUnreleasedChangelogSection(strings.Split(cli.ReadFile(changelogFile), "\n"))
+	return found && section.hasContent
+}
+
+func promptPromoteChangelog(changelogFile string, version string, promote bool) bool {
+	if !changelogHasUnreleasedChanges(changelogFile) {
+		return false
+	}
+
+	return confirm(fmt.Sprintf("Your changelog has an 'Unreleased' section, promote it to %q and commit the change before tagging?", version), "promote-changelog", promote)
+}
+
+// promoteChangelogForRelease promotes the 'Unreleased' section to `version`, commits the changelog
+// and pushes the commit so that the release tag points to it.
+func promoteChangelogForRelease(global *GlobalModel, changelogFile string, version string) {
+	fmt.Println()
+	fmt.Printf("Promoting 'Unreleased' section of %q to %q\n", changelogFile, version)
+
+	promoted, err := promoteChangelog(cli.ReadFile(changelogFile), version, time.Now().Format("2006-01-02"), true)
+	cli.NoError(err, "Unable to promote changelog %q", changelogFile)
+
+	if isDryRun() {
+		activePlan.Step("Write %s promoting 'Unreleased' section to %s", changelogFile, version)
+	} else {
+		cli.WriteFile(changelogFile, "%s", promoted)
+	}
+
+	run("git add", "'"+changelogFile+"'")
+	run("git commit -m", fmt.Sprintf("'Promoted changelog for release %s'", version))
+	run("git push", resolveGitRemote(global))
+}
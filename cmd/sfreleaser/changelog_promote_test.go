@@ -0,0 +1,187 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/streamingfast/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_promoteChangelog(t *testing.T) {
+	tests := []struct {
+		name               string
+		content            string
+		updateCompareLinks bool
+		want               string
+		wantErr            string
+	}{
+		{
+			"simple",
+			`
+			# Changelog
+
+			## Unreleased
+
+			- Some change
+
+			## v1.0.0
+
+			- Initial release
+			`,
+			true,
+			`
+			# Changelog
+
+			## Unreleased
+
+			## v1.1.0 - 2024-01-15
+
+			- Some change
+
+			## v1.0.0
+
+			- Initial release
+			`,
+			"",
+		},
+		{
+			"bracketed with compare links",
+			`
+			# Changelog
+
+			## [Unreleased]
+
+			### Added
+
+			- Some change
+
+			## [v1.0.0] - 2023-12-01
+
+			- Initial release
+
+			[Unreleased]: https://github.com/owner/project/compare/v1.0.0...HEAD
+			[v1.0.0]: https://github.com/owner/project/releases/tag/v1.0.0
+			`,
+			true,
+			`
+			# Changelog
+
+			## [Unreleased]
+
+			## [v1.1.0] - 2024-01-15
+
+			### Added
+
+			- Some change
+
+			## [v1.0.0] - 2023-12-01
+
+			- Initial release
+
+			[Unreleased]: https://github.com/owner/project/compare/v1.1.0...HEAD
+			[v1.1.0]: https://github.com/owner/project/compare/v1.0.0...v1.1.0
+			[v1.0.0]: https://github.com/owner/project/releases/tag/v1.0.0
+			`,
+			"",
+		},
+		{
+			"compare links left untouched",
+			`
+			# Changelog
+
+			## [Unreleased]
+
+			- Some change
+
+			[Unreleased]: https://github.com/owner/project/compare/v1.0.0...HEAD
+			`,
+			false,
+			`
+			# Changelog
+
+			## [Unreleased]
+
+			## [v1.1.0] - 2024-01-15
+
+			- Some change
+
+			[Unreleased]: https://github.com/owner/project/compare/v1.0.0...HEAD
+			`,
+			"",
+		},
+		{
+			"no unreleased section",
+			`
+			# Changelog
+
+			## v1.0.0
+
+			- Initial release
+			`,
+			true,
+			"",
+			"no 'Unreleased' section found, it must be the first '## ' section",
+		},
+		{
+			"empty unreleased section",
+			`
+			# Changelog
+
+			## Unreleased
+
+			## v1.0.0
+
+			- Initial release
+			`,
+			true,
+			"",
+			"'Unreleased' section at line 3 is empty, nothing to promote",
+		},
+		{
+			"version already present",
+			`
+			# Changelog
+
+			## Unreleased
+
+			- Some change
+
+			## 1.1.0
+
+			- Already released
+			`,
+			true,
+			"",
+			`version "v1.1.0" is already present at line 7`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := promoteChangelog(cli.Dedent(tt.content), "v1.1.0", "2024-01-15", tt.updateCompareLinks)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, cli.Dedent(tt.want), got)
+		})
+	}
+}
+
+func Test_promoteChangelog_InvalidDate(t *testing.T) {
+	_, err := promoteChangelog("# Changelog\n\n## Unreleased\n\n- Change\n", "v1.0.0", "15-01-2024", true)
+	require.EqualError(t, err, `invalid date "15-01-2024", expected format YYYY-MM-DD`)
+}
+
+func Test_readReleaseNotes_AfterPromotion(t *testing.T) {
+	promoted, err := promoteChangelog(cli.ReadFile("testdata/changelog/sample.md"), "v1.3.0", "2024-01-15", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "- Some unreleased change\n- Another unreleased feature", readReleaseNotes(createTempChangelog(t, promoted), "v1.3.0"))
+	assert.Equal(t, "- Some unreleased change\n- Another unreleased feature", readReleaseNotes(createTempChangelog(t, promoted), "v1.3.0-rc.1"))
+	assert.Empty(t, lintChangelog(strings.NewReader(promoted), nil))
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func Test_extractChangelogSection(t *testing.T) {
+func Test_extractChangelogRelease(t *testing.T) {
 	tests := []struct {
 		name             string
 		filepath         string
@@ -64,18 +64,19 @@ func Test_extractChangelogSection(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := extractChangelogSection(tt.filepath, tt.startHeaderRegex, tt.endHeaderRegex)
+			release, err := extractChangelogRelease(tt.filepath, tt.startHeaderRegex, tt.endHeaderRegex)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
 			}
 			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+			require.NotNil(t, release)
+			assert.Equal(t, tt.want, release.Body)
 		})
 	}
 }
 
-func Test_extractChangelogSection_withCustomContent(t *testing.T) {
+func Test_extractChangelogRelease_withCustomContent(t *testing.T) {
 	tests := []struct {
 		name             string
 		content          string
@@ -138,9 +139,16 @@ func Test_extractChangelogSection_withCustomContent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpFile := createTempChangelog(t, tt.content)
-			got, err := extractChangelogSection(tmpFile, tt.startHeaderRegex, tt.endHeaderRegex)
+			release, err := extractChangelogRelease(tmpFile, tt.startHeaderRegex, tt.endHeaderRegex)
 			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+
+			if tt.want == "" {
+				assert.Nil(t, release)
+				return
+			}
+
+			require.NotNil(t, release)
+			assert.Equal(t, tt.want, release.Body)
 		})
 	}
 }
@@ -43,3 +43,7 @@ func mapEachReaderLine[T any](reader io.Reader, fn func(line string) T) (out []T
 func dedent(format string, args ...any) string {
 	return fmt.Sprintf(cli.Dedent(format), args...)
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/cli"
+)
+
+const commandFailureExitHandlerID = "command-failure"
+
+// commandFailureMessage is the failure of the running command, empty when it exited through
+// a direct [cli.Exit] (e.g. a failed tool in [runSilent]).
+var commandFailureMessage string
+
+// setupCommandFailure emits the error event (with the step that failed) and calls `report` with
+// the failure message when the running command fails, whichever way it fails: an assertion of
+// the cli library ([cli.NoError], [cli.Ensure], [cli.Quit]), an error returned by the command,
+// an interrupt or a direct [cli.Exit]. Most of them exit the process without returning to the
+// command, so the report is performed by an exit handler.
+func setupCommandFailure(report func(message string)) {
+	cli.OnAssertionFailure = func(message string) {
+		commandFailureMessage = message
+	}
+
+	cli.ExitHandler(commandFailureExitHandlerID, func(code int) {
+		if code == 0 {
+			return
+		}
+
+		// Reporting must not run a second time if something exits while reporting
+		cli.ExitHandler(commandFailureExitHandlerID, nil)
+
+		message := commandFailureMessage
+		if message == "" {
+			message = fmt.Sprintf("exited with code %d", code)
+		}
+
+		emitEvent(&outputEvent{Type: eventError, Message: message})
+		report(message)
+	})
+}
+
+// failCommand records `message` as the failure of the running command and exits, the failure
+// is then reported by the handler installed by [setupCommandFailure].
+func failCommand(message string) {
+	commandFailureMessage = message
+	cli.Exit(1)
+}
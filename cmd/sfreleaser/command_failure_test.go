@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFailingCommandTest runs `test` in a sub-process, a failing 'run' exits the process, and
+// returns its standard output and error.
+func runFailingCommandTest(t *testing.T, test string) (stdout string, stderr string) {
+	cmd := exec.Command(os.Args[0], "-test.run=^"+test+"$")
+	cmd.Env = append(os.Environ(), "SFRELEASER_TEST_COMMAND_FAILURE=true")
+
+	var stdoutBuffer, stderrBuffer strings.Builder
+	cmd.Stdout, cmd.Stderr = &stdoutBuffer, &stderrBuffer
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr, "stdout: %s\nstderr: %s", stdoutBuffer.String(), stderrBuffer.String())
+	assert.Equal(t, 1, exitErr.ExitCode())
+
+	return stdoutBuffer.String(), stderrBuffer.String()
+}
+
+func Test_setupCommandFailure_KnownIssueDiagnosis(t *testing.T) {
+	if os.Getenv("SFRELEASER_TEST_COMMAND_FAILURE") == "true" {
+		ptyDisabled = true
+		setupCommandFailure(printReleaseFailure)
+
+		// The known issue is only found in the command output, not in the failure message
+		run(`bash -c 'printf "upload failed: %s Moved Permanently\n" 307; exit 1'`)
+		return
+	}
+
+	output, _ := runFailingCommandTest(t, "Test_setupCommandFailure_KnownIssueDiagnosis")
+
+	assert.Contains(t, output, "The release failed with the following error:")
+	assert.Contains(t, output, "exit 1\" failed: exit status 1")
+	assert.Contains(t, output, "Found 1 known issue(s) matching the failure:")
+	assert.Contains(t, output, "> upload failed: 307 Moved Permanently")
+}
+
+func Test_setupCommandFailure_ErrorEvent(t *testing.T) {
+	if os.Getenv("SFRELEASER_TEST_COMMAND_FAILURE") == "true" {
+		ptyDisabled = true
+		activeEvents = newEventEmitter(os.Stdout)
+		os.Stdout = os.Stderr
+		setupCommandFailure(printReleaseFailure)
+
+		trackStep(string(releaseStepGoreleaser), func() {
+			run(`bash -c 'exit 1'`)
+		})
+		return
+	}
+
+	stdout, _ := runFailingCommandTest(t, "Test_setupCommandFailure_ErrorEvent")
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	event := &outputEvent{}
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), event))
+
+	assert.Equal(t, eventError, event.Type)
+	assert.Equal(t, string(releaseStepGoreleaser), event.Step)
+	assert.Equal(t, `Command "bash -c exit 1" failed: exit status 1`, event.Message)
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"gopkg.in/yaml.v3"
+)
+
+var ConfigMigrateCmd = Command(configMigrate,
+	"migrate [<file>]",
+	"Rewrite deprecated options of the '.sfreleaser' config file into their replacement",
+	Flags(func(flags *pflag.FlagSet) {
+		flags.Bool("dry-run", false, "Only print the diff of the migrated config file, without writing it")
+		flags.BoolP("yes", "y", false, "Write the migrated config file without asking for confirmation")
+	}),
+	Description(`
+		Rewrites the deprecated options of the config file (defaults to the '.sfreleaser' file used
+		by the other commands) into their replacement. Comments are preserved (blank lines are not)
+		and the diff of the change is printed before being written.
+
+		Migrations applied (with the version that deprecated the rewritten form):
+		- v0.7.0: 'release.upload-substreams-spkg' is replaced by 'release.pre-build-hooks'
+		  (packaging the manifest when it's not an '.spkg' file) and 'release.upload-extra-assets'
+		- v0.12.3: 'install' section is renamed to 'init', like the command
+	`),
+	ExamplePrefixed("sfreleaser config", `
+		# Print what would change
+		migrate --dry-run
+
+		# Migrate without confirmation
+		migrate --yes
+	`),
+)
+
+func configMigrate(cmd *cobra.Command, args []string) error {
+	configFile := viper.ConfigFileUsed()
+	if len(args) > 0 {
+		configFile = args[0]
+	}
+
+	if configFile == "" {
+		return fmt.Errorf("no '.sfreleaser' config file found, provide the file to migrate as argument")
+	}
+
+	dryRun := sflags.MustGetBool(cmd, "dry-run")
+	yes := sflags.MustGetBool(cmd, "yes")
+	nonInteractive = isCIEnvironment()
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("unable to read config file %q: %w", configFile, err)
+	}
+
+	migrated, applied, err := migrateConfig(content)
+	if err != nil {
+		return fmt.Errorf("invalid config file %q: %w", configFile, err)
+	}
+
+	if len(applied) == 0 {
+		fmt.Printf("Config file %q is up to date, nothing to migrate\n", configFile)
+		return nil
+	}
+
+	fmt.Printf("Migrations applicable to %q:\n", configFile)
+	for _, migration := range applied {
+		fmt.Printf("- %s (deprecated since %s)\n", migration.Description, migration.Version)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(content)),
+		B:        difflib.SplitLines(string(migrated)),
+		FromFile: configFile,
+		ToFile:   configFile + " (migrated)",
+		Context:  3,
+	})
+	NoError(err, "Unable to compute diff")
+
+	fmt.Println()
+	fmt.Print(diff)
+	fmt.Println()
+
+	if dryRun {
+		fmt.Println("Dry-run, config file left untouched")
+		return nil
+	}
+
+	if !confirm("Write migrated config file?", "yes", yes) {
+		return fmt.Errorf("operation cancelled by user")
+	}
+
+	WriteFile(configFile, "%s", migrated)
+	fmt.Printf("Wrote %s\n", configFile)
+
+	return nil
+}
+
+// configMigration rewrites a deprecated form of the config file into its replacement, `migrate`
+// receives the root mapping of the config file and returns true if it changed it.
+type configMigration struct {
+	// Version is the sfreleaser version that deprecated the rewritten form
+	Version     string
+	Description string
+	migrate     func(root *yaml.Node) bool
+}
+
+// configMigrations are applied in order, new migrations are added at the end.
+var configMigrations = []*configMigration{
+	{"v0.7.0", "Replace 'release.upload-substreams-spkg' by 'release.pre-build-hooks' and 'release.upload-extra-assets'", migrateUploadSubstreamsSPKG},
+	{"v0.12.3", "Rename 'install' section to 'init'", migrateInstallSection},
+}
+
+// migrateConfig applies all migrations to `content` and returns the migrated content along the
+// applied migrations, `migrated` is nil when no migration applies.
+func migrateConfig(content []byte) (migrated []byte, applied []*configMigration, err error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, nil, fmt.Errorf("parse YAML: %w", err)
+	}
+
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.migrate(document.Content[0]) {
+			applied = append(applied, migration)
+		}
+	}
+
+	if len(applied) == 0 {
+		return nil, nil, nil
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&document); err != nil {
+		return nil, nil, fmt.Errorf("encode YAML: %w", err)
+	}
+
+	return buffer.Bytes(), applied, nil
+}
+
+// substreamsSPKGReplacement returns the 'pre-build-hooks' and 'upload-extra-assets' entries
+// replacing 'upload-substreams-spkg: <value>', a manifest is first packaged in the build directory.
+func substreamsSPKGReplacement(value string) (preBuildHooks []string, uploadExtraAssets []string) {
+	if strings.HasSuffix(value, ".spkg") {
+		return nil, []string{value}
+	}
+
+	spkg := "{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg"
+	return []string{fmt.Sprintf(`substreams pack -o "%s" %s`, spkg, value)}, []string{spkg}
+}
+
+func migrateUploadSubstreamsSPKG(root *yaml.Node) bool {
+	release := yamlMappingValue(root, "release")
+	if release == nil || release.Kind != yaml.MappingNode {
+		return false
+	}
+
+	key, value := yamlRemoveMappingKey(release, "upload-substreams-spkg")
+	if key == nil {
+		return false
+	}
+
+	if value.Kind == yaml.ScalarNode && value.Value != "" {
+		preBuildHooks, uploadExtraAssets := substreamsSPKGReplacement(value.Value)
+
+		// The comment of the removed key moves to the first key created
+		if len(preBuildHooks) > 0 && yamlAppendToSequence(release, "pre-build-hooks", key.HeadComment, preBuildHooks...) {
+			key.HeadComment = ""
+		}
+
+		yamlAppendToSequence(release, "upload-extra-assets", key.HeadComment, uploadExtraAssets...)
+	}
+
+	return true
+}
+
+func migrateInstallSection(root *yaml.Node) bool {
+	install := yamlMappingValue(root, "install")
+	if install == nil {
+		return false
+	}
+
+	initSection := yamlMappingValue(root, "init")
+	if initSection == nil {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "install" {
+				root.Content[i].Value = "init"
+			}
+		}
+
+		return true
+	}
+
+	// Both exist, values already defined under 'init' win like they would have for 'sfreleaser init'
+	if initSection.Kind == yaml.MappingNode && install.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(install.Content); i += 2 {
+			if yamlMappingValue(initSection, install.Content[i].Value) == nil {
+				initSection.Content = append(initSection.Content, install.Content[i], install.Content[i+1])
+			}
+		}
+	}
+
+	yamlRemoveMappingKey(root, "install")
+	return true
+}
+
+func yamlMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// yamlRemoveMappingKey removes `key` from `mapping` returning its key and value nodes, both nil
+// if the key was not found.
+func yamlRemoveMappingKey(mapping *yaml.Node, key string) (keyNode *yaml.Node, valueNode *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			keyNode, valueNode = mapping.Content[i], mapping.Content[i+1]
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+			return
+		}
+	}
+
+	return nil, nil
+}
+
+// yamlAppendToSequence appends `values` to the sequence under `key` in `mapping`, creating it
+// (with `headComment` as its comment) if it doesn't exist in which case true is returned. A
+// single scalar value is converted to a sequence holding it.
+func yamlAppendToSequence(mapping *yaml.Node, key string, headComment string, values ...string) (created bool) {
+	var nodes []*yaml.Node
+	for _, value := range values {
+		nodes = append(nodes, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+	}
+
+	sequence := yamlMappingValue(mapping, key)
+	switch {
+	case sequence == nil:
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, HeadComment: headComment},
+			&yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: nodes},
+		)
+		return true
+
+	case sequence.Kind == yaml.SequenceNode:
+		sequence.Content = append(sequence.Content, nodes...)
+
+	case sequence.Kind == yaml.ScalarNode && sequence.ShortTag() == "!!null":
+		*sequence = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: nodes}
+
+	default:
+		existing := *sequence
+		*sequence = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: append([]*yaml.Node{&existing}, nodes...)}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_migrateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		wantApplied []string
+	}{
+		{
+			"up to date",
+			dedent(`
+				global:
+				  language: golang
+			`),
+			"",
+			nil,
+		},
+		{
+			"substreams manifest",
+			dedent(`
+				release:
+				  # Attach the package
+				  upload-substreams-spkg: substreams.yaml
+			`),
+			dedent(`
+				release:
+				  # Attach the package
+				  pre-build-hooks:
+				    - substreams pack -o "{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg" substreams.yaml
+				  upload-extra-assets:
+				    - '{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg'
+			`),
+			[]string{"v0.7.0"},
+		},
+		{
+			"substreams manifest with existing hooks",
+			dedent(`
+				release:
+				  pre-build-hooks:
+				    - make protogen # generated first
+				  # Attach the package
+				  upload-substreams-spkg: substreams.yaml
+				  upload-extra-assets: README.md
+			`),
+			dedent(`
+				release:
+				  pre-build-hooks:
+				    - make protogen # generated first
+				    - substreams pack -o "{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg" substreams.yaml
+				  upload-extra-assets:
+				    - README.md
+				    - '{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg'
+			`),
+			[]string{"v0.7.0"},
+		},
+		{
+			"substreams package",
+			dedent(`
+				release:
+				  upload-substreams-spkg: ./build/package.spkg
+				  upload-extra-assets:
+			`),
+			dedent(`
+				release:
+				  upload-extra-assets:
+				    - ./build/package.spkg
+			`),
+			[]string{"v0.7.0"},
+		},
+		{
+			"install section renamed in place",
+			dedent(`
+				install:
+				  overwrite: true # always
+				global:
+				  language: golang
+			`),
+			dedent(`
+				init:
+				  overwrite: true # always
+				global:
+				  language: golang
+			`),
+			[]string{"v0.12.3"},
+		},
+		{
+			"install section merged in init",
+			dedent(`
+				init:
+				  overwrite: false
+				install:
+				  overwrite: true
+				  detect-only: true
+			`),
+			dedent(`
+				init:
+				  overwrite: false
+				  detect-only: true
+			`),
+			[]string{"v0.12.3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, applied, err := migrateConfig([]byte(tt.content))
+			require.NoError(t, err)
+
+			var appliedVersions []string
+			for _, migration := range applied {
+				appliedVersions = append(appliedVersions, migration.Version)
+			}
+
+			assert.Equal(t, tt.wantApplied, appliedVersions)
+			assert.Equal(t, tt.want, strings.TrimSuffix(string(migrated), "\n"))
+		})
+	}
+}
+
+func Test_substreamsSPKGReplacement(t *testing.T) {
+	hooks, assets := substreamsSPKGReplacement("substreams.yaml")
+	assert.Equal(t, []string{`substreams pack -o "{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg" substreams.yaml`}, hooks)
+	assert.Equal(t, []string{"{{ .buildDir }}/{{ .global.Project }}-{{ .release.Version }}.spkg"}, assets)
+
+	hooks, assets = substreamsSPKGReplacement("package.spkg")
+	assert.Nil(t, hooks)
+	assert.Equal(t, []string{"package.spkg"}, assets)
+}
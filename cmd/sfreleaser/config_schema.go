@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+)
+
+var ConfigSchemaCmd = Command(configSchema,
+	"schema",
+	"Print the JSON Schema of the '.sfreleaser' config file",
+	Description(`
+		Prints the JSON Schema (draft 2020-12) describing the '.sfreleaser' config file. The schema
+		is generated from the flags of every command: persistent flags are accepted under 'global'
+		and the flags of a command under its name (e.g. 'release', 'build').
+
+		Save it in your project and reference it from the config file so that editors supporting
+		the YAML language server can validate and autocomplete it:
+
+		  # yaml-language-server: $schema=./.sfreleaser.schema.json
+	`),
+	ExamplePrefixed("sfreleaser config", `
+		schema > .sfreleaser.schema.json
+	`),
+)
+
+func configSchema(cmd *cobra.Command, _ []string) error {
+	content, err := json.MarshalIndent(buildConfigSchema(cmd.Root()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal schema: %w", err)
+	}
+
+	fmt.Println(string(content))
+	return nil
+}
+
+// jsonSchema is the subset of JSON Schema needed to describe the '.sfreleaser' config file.
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	// AdditionalProperties is either false (no other property accepted) or the schema of the other properties
+	AdditionalProperties any           `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema   `json:"items,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	OneOf                []*jsonSchema `json:"oneOf,omitempty"`
+	Enum                 []string      `json:"enum,omitempty"`
+	Default              any           `json:"default,omitempty"`
+	Deprecated           bool          `json:"deprecated,omitempty"`
+}
+
+func newObjectSchema(description string) *jsonSchema {
+	return &jsonSchema{
+		Description:          description,
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{},
+		AdditionalProperties: false,
+	}
+}
+
+// configEnum lists the accepted values of a config key, `resolve` maps accepted aliases
+// (e.g. 'go' for 'golang') to their actual value.
+type configEnum struct {
+	values  []string
+	resolve func(in string) string
+}
+
+// configEnums are the config keys restricted to a set of values, keyed by their full path.
+var configEnums = map[string]*configEnum{
+	"global.language": {configEnumValues(LanguageNames()), LanguageResolveAlias},
+	"global.variant":  {configEnumValues(VariantNames()), VariantResolveAlias},
+	"release.on-failure": {
+		[]string{string(releaseFailureKeep), string(releaseFailureDeleteDraft), string(releaseFailurePrompt)},
+		func(in string) string { return in },
+	},
+}
+
+// configEnumFor returns the enum of config key `path`, the one of the overlaid key for a profile
+// key (e.g. 'profiles.<name>.global.language') and the one of the global key for a hook condition
+// (e.g. 'release.pre-build-hooks[0].only-for.language[1]'), nil if the key is not an enum.
+func configEnumFor(path string) *configEnum {
+	if strings.HasPrefix(path, "profiles.") {
+		if parts := strings.SplitN(path, ".", 3); len(parts) == 3 {
+			path = parts[2]
+		}
+	}
+
+	if _, condition, found := strings.Cut(path, ".only-for."); found {
+		path = "global." + strings.TrimRight(condition, "[]0123456789")
+	}
+
+	return configEnums[path]
+}
+
+func configEnumValues(names []string) (values []string) {
+	for _, name := range names {
+		if name == "Unset" {
+			continue
+		}
+
+		values = append(values, strings.ToLower(name))
+	}
+
+	return
+}
+
+func (e *configEnum) accepts(value string) bool {
+	resolved := e.resolve(value)
+	return slices.ContainsFunc(e.values, func(candidate string) bool { return strings.EqualFold(candidate, resolved) })
+}
+
+// buildConfigSchema generates the schema of the config file from the flags registered on `root`
+// and its sub-commands, mirroring how flags are bound to viper keys (see [cli.ConfigureViper]).
+func buildConfigSchema(root *cobra.Command) *jsonSchema {
+	schema := newObjectSchema("Configuration of sfreleaser, each value can be overridden by its flag")
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.Title = "sfreleaser configuration file (.sfreleaser)"
+
+	global := newObjectSchema("Options applying to all commands")
+	root.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		global.Properties[flag.Name] = flagSchema("global."+flag.Name, flag)
+	})
+	schema.Properties["global"] = global
+
+	for _, child := range root.Commands() {
+		addCommandConfigSchema(schema, child, child.Name())
+	}
+
+	profile := newObjectSchema("Profile selected with '--profile <name>', its values overlay the config file ones")
+	for _, section := range configProfileSections {
+		if sectionSchema, found := schema.Properties[section]; found {
+			profile.Properties[section] = sectionSchema
+		}
+	}
+
+	profiles := newObjectSchema("Named profiles, each one overlaying the 'global', 'release' and 'build' values")
+	profiles.AdditionalProperties = profile
+	schema.Properties["profiles"] = profiles
+
+	return schema
+}
+
+func addCommandConfigSchema(parent *jsonSchema, cmd *cobra.Command, path string) {
+	section := newObjectSchema(fmt.Sprintf("Options of command '%s'", cmd.CommandPath()))
+	cmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		// Added by cobra on the executing command, it's not a configuration option
+		if flag.Name == "help" {
+			return
+		}
+
+		section.Properties[flag.Name] = flagSchema(path+"."+flag.Name, flag)
+	})
+
+	for _, child := range cmd.Commands() {
+		addCommandConfigSchema(section, child, path+"."+child.Name())
+	}
+
+	if len(section.Properties) > 0 {
+		parent.Properties[cmd.Name()] = section
+	}
+}
+
+func flagSchema(path string, flag *pflag.Flag) *jsonSchema {
+	schema := &jsonSchema{Description: flag.Usage}
+	if flag.Deprecated != "" {
+		schema.Description = fmt.Sprintf("%s (deprecated, %s)", flag.Usage, flag.Deprecated)
+		schema.Deprecated = true
+	}
+
+	switch flag.Value.Type() {
+	case "bool":
+		schema.Type = "boolean"
+		if value, err := strconv.ParseBool(flag.DefValue); err == nil && value {
+			schema.Default = value
+		}
+
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		schema.Type = "integer"
+		if value, err := strconv.ParseInt(flag.DefValue, 10, 64); err == nil && value != 0 {
+			schema.Default = value
+		}
+
+	case "stringArray", "stringSlice":
+		schema.Type = "array"
+		schema.Items = &jsonSchema{Type: "string"}
+		if strings.HasSuffix(flag.Name, "-hooks") {
+			schema.Items = &jsonSchema{OneOf: []*jsonSchema{{Type: "string", Description: "The command to run"}, hookConfigSchema()}}
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok && len(slice.GetSlice()) > 0 {
+			schema.Default = slice.GetSlice()
+		}
+
+	default:
+		schema.Type = "string"
+		if flag.DefValue != "" {
+			schema.Default = flag.DefValue
+		}
+	}
+
+	if enum := configEnumFor(path); enum != nil {
+		schema.Enum = enum.values
+	}
+
+	return schema
+}
+
+// hookConfigSchema is the schema of the mapping form of a hook, see [releaseHook].
+func hookConfigSchema() *jsonSchema {
+	conditionSchema := func(description string, values []string) *jsonSchema {
+		return &jsonSchema{Description: description, OneOf: []*jsonSchema{
+			{Type: "string", Enum: values},
+			{Type: "array", Items: &jsonSchema{Type: "string", Enum: values}},
+		}}
+	}
+
+	onlyFor := newObjectSchema("Run the hook only for these languages and/or variants")
+	onlyFor.Properties["language"] = conditionSchema("The language(s) the hook runs for", configEnumValues(LanguageNames()))
+	onlyFor.Properties["variant"] = conditionSchema("The variant(s) the hook runs for", configEnumValues(VariantNames()))
+
+	schema := newObjectSchema("The hook with its options")
+	schema.Required = []string{"command"}
+	schema.Properties["command"] = &jsonSchema{Description: "The command to run, it can be templated", Type: "string"}
+	schema.Properties["working-dir"] = &jsonSchema{Description: "The directory the command runs in, relative to the project directory", Type: "string"}
+	schema.Properties["env"] = &jsonSchema{Description: "Extra environment variables of the command as 'KEY=value' entries, values can be templated", Type: "array", Items: &jsonSchema{Type: "string"}}
+	schema.Properties["timeout"] = &jsonSchema{Description: "Kill the command if it's still running after this duration (e.g. '30s', '5m')", Type: "string"}
+	schema.Properties["continue-on-error"] = &jsonSchema{Description: "Continue the build or release if the command fails", Type: "boolean"}
+	schema.Properties["only-for"] = onlyFor
+
+	return schema
+}
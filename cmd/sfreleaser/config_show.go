@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	. "github.com/streamingfast/cli"
+)
+
+var ConfigShowCmd = Command(configShow,
+	"show",
+	"Print the effective configuration and where each value comes from",
+	Description(`
+		Prints the configuration as 'sfreleaser release' and 'sfreleaser build' would resolve it:
+		the resolved global, release and build models followed by the value of every option.
+
+		Each option value is printed with the source that won, from highest to lowest priority:
+		- flag: provided on the command line (only the global flags can be provided to this command)
+		- env: provided through its 'SFRELEASER_<SECTION>_<NAME>' environment variable
+		- profile: provided by the profile selected with '--profile' in the '.sfreleaser' config file
+		- file: provided in the '.sfreleaser' config file
+		- default: the default value of the flag
+	`),
+	ExamplePrefixed("sfreleaser config", `
+		show
+
+		# Options provided as global flags are reflected too
+		--variant=library show
+	`),
+)
+
+func configShow(cmd *cobra.Command, _ []string) error {
+	root := cmd.Root()
+
+	configFile := viper.ConfigFileUsed()
+	if configFile != "" {
+		fmt.Printf("Config file: %s\n", configFile)
+	} else {
+		fmt.Println("Config file: <none>")
+	}
+
+	if activeProfile != "" {
+		fmt.Printf("Profile: %s\n", activeProfile)
+	}
+
+	global := mustGetGlobal(cmd)
+	printConfigModel("Global", global)
+
+	if global.Language != LanguageUnset {
+		release := &ReleaseModel{}
+		release.populate(mustFindCommand(root, "release"), global)
+		printConfigModel("Release", release)
+	} else {
+		fmt.Println()
+		fmt.Println("Release: <Language not set, unable to resolve>")
+	}
+
+	build := &BuildModel{}
+	build.populate(mustFindCommand(root, "build"))
+	printConfigModel("Build", build)
+
+	fmt.Println()
+	fmt.Println("Values:")
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, key := range configKeys(buildConfigSchema(root), "") {
+		fmt.Fprintf(writer, "  %s\t%s\t(%s)\n", key, formatConfigValue(viper.Get(key)), configValueSource(cmd, key, configFile))
+	}
+
+	return writer.Flush()
+}
+
+func mustFindCommand(root *cobra.Command, name string) *cobra.Command {
+	found, _, err := root.Find([]string{name})
+	NoError(err, "Unable to find command %q", name)
+
+	return found
+}
+
+func printConfigModel(title string, model any) {
+	content, err := json.MarshalIndent(model, "  ", "  ")
+	NoError(err, "Unable to marshal %s model", strings.ToLower(title))
+
+	fmt.Println()
+	fmt.Printf("%s:\n  %s\n", title, content)
+}
+
+// configKeys returns the sorted full keys (e.g. 'release.publish-now') of every option in `schema`.
+func configKeys(schema *jsonSchema, prefix string) (keys []string) {
+	for name, property := range schema.Properties {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if property.Type == "object" {
+			keys = append(keys, configKeys(property, key)...)
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// configValueSource returns the source that won for `key`, following viper's priority which is
+// flag, environment variable, config file and finally flag's default value.
+func configValueSource(cmd *cobra.Command, key string, configFile string) string {
+	if flag := findConfigFlag(cmd, key); flag != nil && flag.Changed {
+		return "flag --" + flag.Name
+	}
+
+	env := configEnvVar(key)
+	if value, found := os.LookupEnv(env); found && value != "" {
+		return "env " + env
+	}
+
+	if activeProfile != "" && viper.InConfig("profiles."+activeProfile+"."+key) {
+		return fmt.Sprintf("profile %s in %s", activeProfile, configFile)
+	}
+
+	if viper.InConfig(key) {
+		return "file " + configFile
+	}
+
+	return "default"
+}
+
+// findConfigFlag returns the flag of `cmd` bound to config `key`, nil if `cmd` has no such flag.
+func findConfigFlag(cmd *cobra.Command, key string) (found *pflag.Flag) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if slices.Contains(flag.Annotations[ReboundFlagAnnotation], key) {
+			found = flag
+		}
+	})
+
+	return
+}
+
+// configEnvVar returns the environment variable name of config `key`, following the rules
+// configured by [ConfigureViper].
+func configEnvVar(key string) string {
+	return "SFRELEASER_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+func formatConfigValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+
+	case []string:
+		quoted := make([]string, len(v))
+		for i, element := range v {
+			quoted[i] = fmt.Sprintf("%q", element)
+		}
+
+		return "[" + strings.Join(quoted, ", ") + "]"
+
+	case []any:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			elements[i] = formatConfigValue(element)
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_configKeys(t *testing.T) {
+	assert.Equal(t, []string{
+		"changelog.lint.extra-sections",
+		"global.language",
+		"global.sfreleaser-min-version",
+		"global.variant",
+		"release.brew-tap-repo",
+		"release.on-failure",
+		"release.pre-build-hooks",
+		"release.publish-now",
+		"release.upload-substreams-spkg",
+	}, configKeys(buildConfigSchema(newTestConfigRoot()), ""))
+}
+
+func Test_configValueSource(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cmd := &cobra.Command{Use: "show"}
+	cmd.Flags().String("variant", "", "The variant")
+	cmd.Flags().String("language", "", "The language")
+	cmd.Flags().Lookup("variant").Annotations = map[string][]string{cli.ReboundFlagAnnotation: {"global.variant"}}
+	cmd.Flags().Lookup("language").Annotations = map[string][]string{cli.ReboundFlagAnnotation: {"global.language"}}
+	require.NoError(t, cmd.Flags().Set("variant", "library"))
+
+	viper.SetConfigType("yaml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(dedent(`
+		global:
+		  variant: application
+		  language: golang
+		  license: MIT
+		release:
+		  brew-tap-repo: homebrew-tap
+	`))))
+
+	t.Setenv("SFRELEASER_RELEASE_BREW_TAP_REPO", "other-tap")
+	t.Setenv("SFRELEASER_RELEASE_PUBLISH_NOW", "")
+
+	assert.Equal(t, "flag --variant", configValueSource(cmd, "global.variant", ".sfreleaser"))
+	assert.Equal(t, "file .sfreleaser", configValueSource(cmd, "global.language", ".sfreleaser"))
+	assert.Equal(t, "file .sfreleaser", configValueSource(cmd, "global.license", ".sfreleaser"))
+	assert.Equal(t, "env SFRELEASER_RELEASE_BREW_TAP_REPO", configValueSource(cmd, "release.brew-tap-repo", ".sfreleaser"))
+	assert.Equal(t, "default", configValueSource(cmd, "release.publish-now", ".sfreleaser"), "empty environment variables are ignored")
+}
+
+func Test_formatConfigValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"string", "homebrew-tap", `"homebrew-tap"`},
+		{"bool", true, "true"},
+		{"string slice", []string{"make build", "make spkg"}, `["make build", "make spkg"]`},
+		{"empty slice", []string{}, "[]"},
+		{"yaml list", []any{"a", 1}, `["a", 1]`},
+		{"unset", nil, "<nil>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatConfigValue(tt.value))
+		})
+	}
+}
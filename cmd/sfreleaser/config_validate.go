@@ -0,0 +1,318 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	. "github.com/streamingfast/cli"
+	"gopkg.in/yaml.v3"
+)
+
+var ConfigValidateCmd = Command(configValidate,
+	"validate [<file>]",
+	"Validate the '.sfreleaser' config file against the known options",
+	Description(`
+		Validates the config file (defaults to the '.sfreleaser' file used by the other commands)
+		against the schema printed by 'sfreleaser config schema'.
+
+		The following problems are reported with their line number:
+		- Unknown keys (e.g. 'global.varient'), with a suggestion when a known key is close
+		- Values of the wrong type (e.g. a string where a list or a boolean is expected)
+		- Invalid 'global.language', 'global.variant' and 'release.on-failure' values (and hooks 'only-for' conditions)
+		- Hooks in the mapping form without a 'command'
+
+		The command exits with a non-zero exit code if at least one problem is found, making
+		it suitable for CI usage.
+	`),
+	ExamplePrefixed("sfreleaser config", `
+		# Validate the project's .sfreleaser file
+		validate
+
+		# Validate a specific file
+		validate path/to/.sfreleaser
+	`),
+)
+
+func configValidate(cmd *cobra.Command, args []string) error {
+	configFile := viper.ConfigFileUsed()
+	if len(args) > 0 {
+		configFile = args[0]
+	}
+
+	if configFile == "" {
+		return fmt.Errorf("no '.sfreleaser' config file found, provide the file to validate as argument")
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("unable to read config file %q: %w", configFile, err)
+	}
+
+	diagnostics, err := validateConfig(content, buildConfigSchema(cmd.Root()))
+	if err != nil {
+		return fmt.Errorf("invalid config file %q: %w", configFile, err)
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s:%s\n", configFile, diagnostic)
+	}
+
+	if len(diagnostics) > 0 {
+		return fmt.Errorf("config file %q has %d error(s)", configFile, len(diagnostics))
+	}
+
+	fmt.Printf("Config file %q is valid\n", configFile)
+	return nil
+}
+
+type configDiagnostic struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+func (d *configDiagnostic) String() string {
+	return fmt.Sprintf("%d: %s: %s", d.Line, d.Path, d.Message)
+}
+
+// validateConfig checks the YAML `content` against `schema`, an error is returned only if
+// the content is not valid YAML, problems found are returned as diagnostics.
+func validateConfig(content []byte, schema *jsonSchema) (diagnostics []*configDiagnostic, err error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+
+	// An empty file is a valid config
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	report := func(node *yaml.Node, path string, format string, args ...any) {
+		diagnostics = append(diagnostics, &configDiagnostic{node.Line, path, fmt.Sprintf(format, args...)})
+	}
+
+	validateConfigNode(document.Content[0], schema, "", report)
+	return diagnostics, nil
+}
+
+func validateConfigNode(node *yaml.Node, schema *jsonSchema, path string, report func(node *yaml.Node, path string, format string, args ...any)) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	// An empty value leaves the option unset, which is always valid
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return
+	}
+
+	if len(schema.OneOf) > 0 {
+		alternative := schemaAlternativeFor(node, schema.OneOf)
+		if alternative == nil {
+			report(node, path, "expected %s, got %s", strings.Join(schemaTypeNames(schema, "a "), " or "), describeYAMLNode(node))
+			return
+		}
+
+		schema = alternative
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			report(node, path, "expected a mapping, got %s", describeYAMLNode(node))
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := key.Value
+			if path != "" {
+				keyPath = path + "." + key.Value
+			}
+
+			// Viper keys are case insensitive
+			property, found := schema.Properties[strings.ToLower(key.Value)]
+			if additional, ok := schema.AdditionalProperties.(*jsonSchema); !found && ok {
+				property, found = additional, true
+			}
+
+			if !found {
+				if suggestion := closestConfigKey(key.Value, schema); suggestion != "" {
+					report(key, keyPath, "unknown key, did you mean %q?", suggestion)
+				} else {
+					report(key, keyPath, "unknown key")
+				}
+				continue
+			}
+
+			validateConfigNode(value, property, keyPath, report)
+		}
+
+		for _, required := range schema.Required {
+			found := false
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				found = found || strings.EqualFold(node.Content[i].Value, required)
+			}
+
+			if !found {
+				report(node, path, "missing required key %q", required)
+			}
+		}
+
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			report(node, path, "expected a list of %s, got %s", strings.Join(schemaTypeNames(schema.Items, ""), " or "), describeYAMLNode(node))
+			return
+		}
+
+		for i, item := range node.Content {
+			validateConfigNode(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), report)
+		}
+
+	default:
+		if node.Kind != yaml.ScalarNode || !yamlScalarHasType(node, schema.Type) {
+			report(node, path, "expected a %s, got %s", schema.Type, describeYAMLNode(node))
+			return
+		}
+
+		if enum := configEnumFor(path); enum != nil && !enum.accepts(node.Value) {
+			report(node, path, "invalid value %q, accepted values are %s", node.Value, strings.Join(enum.values, ", "))
+		}
+	}
+}
+
+// schemaAlternativeFor returns the alternative of a 'oneOf' schema matching the kind of `node`, nil if none does.
+func schemaAlternativeFor(node *yaml.Node, alternatives []*jsonSchema) *jsonSchema {
+	for _, alternative := range alternatives {
+		switch alternative.Type {
+		case "object":
+			if node.Kind == yaml.MappingNode {
+				return alternative
+			}
+		case "array":
+			if node.Kind == yaml.SequenceNode {
+				return alternative
+			}
+		default:
+			if node.Kind == yaml.ScalarNode {
+				return alternative
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaTypeNames returns the YAML names of the types accepted by `schema`, singular when
+// `article` is set (e.g. 'a string', 'a mapping') and plural otherwise (e.g. 'strings').
+func schemaTypeNames(schema *jsonSchema, article string) (names []string) {
+	alternatives := schema.OneOf
+	if len(alternatives) == 0 {
+		alternatives = []*jsonSchema{schema}
+	}
+
+	for _, alternative := range alternatives {
+		name := alternative.Type
+		switch name {
+		case "object":
+			name = "mapping"
+		case "array":
+			name = "list"
+		}
+
+		if article != "" {
+			names = append(names, article+name)
+		} else {
+			names = append(names, name+"s")
+		}
+	}
+
+	return names
+}
+
+func yamlScalarHasType(node *yaml.Node, schemaType string) bool {
+	tag := node.ShortTag()
+
+	switch schemaType {
+	case "boolean":
+		return tag == "!!bool"
+	case "integer":
+		return tag == "!!int"
+	case "string":
+		// Numbers are accepted as strings, e.g. 'sfreleaser-min-version: 0.7'
+		return tag == "!!str" || tag == "!!int" || tag == "!!float"
+	}
+
+	return false
+}
+
+func describeYAMLNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+
+	return fmt.Sprintf("%q", node.Value)
+}
+
+// closestConfigKey returns the property of `schema` closest to `key`, if it's close enough
+// to be a typo of it or if it's the only property `key` is a truncation of (e.g. 'brew-tap'
+// for 'brew-tap-repo'), an empty string otherwise.
+func closestConfigKey(key string, schema *jsonSchema) string {
+	key = strings.ToLower(key)
+
+	candidates := make([]string, 0, len(schema.Properties))
+	for candidate := range schema.Properties {
+		candidates = append(candidates, candidate)
+	}
+	sort.Strings(candidates)
+
+	best, bestDistance := "", 3
+	var extending []string
+	for _, candidate := range candidates {
+		if distance := levenshteinDistance(key, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+
+		if strings.HasPrefix(candidate, key+"-") {
+			extending = append(extending, candidate)
+		}
+	}
+
+	if best == "" && len(extending) == 1 {
+		return extending[0]
+	}
+
+	return best
+}
+
+func levenshteinDistance(left, right string) int {
+	previous := make([]int, len(right)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(left); i++ {
+		current := make([]int, len(right)+1)
+		current[0] = i
+
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous = current
+	}
+
+	return previous[len(right)]
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfigRoot() *cobra.Command {
+	root := &cobra.Command{Use: "sfreleaser"}
+	root.PersistentFlags().String("language", "", "The language")
+	root.PersistentFlags().String("variant", "", "The variant")
+	root.PersistentFlags().String("sfreleaser-min-version", "", "The minimum version")
+
+	release := &cobra.Command{Use: "release"}
+	release.Flags().Bool("publish-now", false, "Publish now")
+	release.Flags().String("brew-tap-repo", "homebrew-tap", "The tap")
+	release.Flags().StringArray("pre-build-hooks", nil, "The hooks")
+	release.Flags().String("on-failure", "keep", "The policy")
+	release.Flags().String("upload-substreams-spkg", "", "The package")
+	release.Flags().Lookup("upload-substreams-spkg").Deprecated = "use hooks"
+
+	changelog := &cobra.Command{Use: "changelog"}
+	lint := &cobra.Command{Use: "lint"}
+	lint.Flags().StringArray("extra-sections", nil, "Extra sections")
+	changelog.AddCommand(lint)
+
+	root.AddCommand(release, changelog, &cobra.Command{Use: "doctor"})
+	return root
+}
+
+func Test_buildConfigSchema(t *testing.T) {
+	schema := buildConfigSchema(newTestConfigRoot())
+
+	require.Contains(t, schema.Properties, "global")
+	require.Contains(t, schema.Properties, "release")
+	assert.NotContains(t, schema.Properties, "doctor", "commands without flags have no section")
+
+	assert.Equal(t, []string{"golang", "rust"}, schema.Properties["global"].Properties["language"].Enum)
+	assert.Equal(t, []string{"application", "library", "substreams"}, schema.Properties["global"].Properties["variant"].Enum)
+
+	release := schema.Properties["release"].Properties
+	assert.Equal(t, &jsonSchema{Description: "Publish now", Type: "boolean"}, release["publish-now"])
+	assert.Equal(t, &jsonSchema{Description: "The tap", Type: "string", Default: "homebrew-tap"}, release["brew-tap-repo"])
+	assert.Equal(t, "array", release["pre-build-hooks"].Type)
+	require.Len(t, release["pre-build-hooks"].Items.OneOf, 2, "hooks accept the string and the mapping forms")
+	assert.Equal(t, "string", release["pre-build-hooks"].Items.OneOf[0].Type)
+	assert.Equal(t, []string{"command"}, release["pre-build-hooks"].Items.OneOf[1].Required)
+	assert.True(t, release["upload-substreams-spkg"].Deprecated)
+
+	assert.Contains(t, schema.Properties["changelog"].Properties["lint"].Properties, "extra-sections")
+}
+
+func Test_validateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"empty", "", nil},
+		{
+			"valid",
+			dedent(`
+				global:
+				  language: Go
+				  variant: lib
+				  sfreleaser-min-version: 0.7
+				release:
+				  publish-now: true
+				  brew-tap-repo:
+				  pre-build-hooks:
+				    - make build
+				changelog:
+				  lint:
+				    extra-sections: [Performance]
+			`),
+			nil,
+		},
+		{
+			"unknown keys",
+			dedent(`
+				global:
+				  varient: application
+				release:
+				  brew-tap: homebrew-tap
+				  unrelated: true
+				relase:
+				  publish-now: true
+			`),
+			[]string{
+				`2: global.varient: unknown key, did you mean "variant"?`,
+				`4: release.brew-tap: unknown key, did you mean "brew-tap-repo"?`,
+				`5: release.unrelated: unknown key`,
+				`6: relase: unknown key, did you mean "release"?`,
+			},
+		},
+		{
+			"wrong types",
+			dedent(`
+				global: golang
+				release:
+				  publish-now: "yes"
+				  pre-build-hooks: make build
+				  brew-tap-repo: [tap]
+				changelog:
+				  lint:
+				    extra-sections:
+				      - key: value
+			`),
+			[]string{
+				`1: global: expected a mapping, got "golang"`,
+				`3: release.publish-now: expected a boolean, got "yes"`,
+				`4: release.pre-build-hooks: expected a list of strings or mappings, got "make build"`,
+				`5: release.brew-tap-repo: expected a string, got a list`,
+				`9: changelog.lint.extra-sections[0]: expected a string, got a mapping`,
+			},
+		},
+		{
+			"profiles",
+			dedent(`
+				profiles:
+				  public:
+				    global:
+				      variant: libary
+				    release:
+				      publish-now: true
+				      brew-tap: homebrew-tap
+				    changelog:
+				      lint:
+				  internal: []
+			`),
+			[]string{
+				`4: profiles.public.global.variant: invalid value "libary", accepted values are application, library, substreams`,
+				`7: profiles.public.release.brew-tap: unknown key, did you mean "brew-tap-repo"?`,
+				`8: profiles.public.changelog: unknown key`,
+				`10: profiles.internal: expected a mapping, got a list`,
+			},
+		},
+		{
+			"hooks",
+			dedent(`
+				release:
+				  pre-build-hooks:
+				    - make build
+				    - command: ./package.sh
+				      timeout: 5m
+				      continue-on-error: true
+				      only-for:
+				        language: go
+				        variant: [lib, substreams]
+				    - working-dir: scripts
+				      continue-on-error: "yes"
+				      only-for:
+				        variant: [plugin]
+				    - [make, build]
+			`),
+			[]string{
+				`11: release.pre-build-hooks[2].continue-on-error: expected a boolean, got "yes"`,
+				`13: release.pre-build-hooks[2].only-for.variant[0]: invalid value "plugin", accepted values are application, library, substreams`,
+				`10: release.pre-build-hooks[2]: missing required key "command"`,
+				`14: release.pre-build-hooks[3]: expected a string or a mapping, got a list`,
+			},
+		},
+		{
+			"invalid enums",
+			dedent(`
+				global:
+				  language: python
+				  variant: plugin
+				release:
+				  on-failure: delete
+			`),
+			[]string{
+				`2: global.language: invalid value "python", accepted values are golang, rust`,
+				`3: global.variant: invalid value "plugin", accepted values are application, library, substreams`,
+				`5: release.on-failure: invalid value "delete", accepted values are keep, delete-draft, prompt`,
+			},
+		},
+	}
+
+	schema := buildConfigSchema(newTestConfigRoot())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := validateConfig([]byte(tt.content), schema)
+			require.NoError(t, err)
+
+			var actual []string
+			for _, diagnostic := range diagnostics {
+				actual = append(actual, diagnostic.String())
+			}
+
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_validateConfig_InvalidYAML(t *testing.T) {
+	_, err := validateConfig([]byte("global: [\n"), buildConfigSchema(newTestConfigRoot()))
+	assert.ErrorContains(t, err, "parse YAML")
+}
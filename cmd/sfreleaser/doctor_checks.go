@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	versioning "github.com/hashicorp/go-version"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/sfreleaser/github"
+	"go.uber.org/zap"
+)
+
+type doctorStatus string
+
+const (
+	doctorPass doctorStatus = "PASS"
+	doctorWarn doctorStatus = "WARN"
+	doctorFail doctorStatus = "FAIL"
+	doctorSkip doctorStatus = "SKIP"
+)
+
+type doctorCheckResult struct {
+	Status  doctorStatus
+	Message string
+	// Remediation explains how to fix the problem, it's printed only when the check did not pass
+	Remediation string
+}
+
+func passed(format string, args ...any) *doctorCheckResult {
+	return &doctorCheckResult{Status: doctorPass, Message: fmt.Sprintf(format, args...)}
+}
+
+func skipped(format string, args ...any) *doctorCheckResult {
+	return &doctorCheckResult{Status: doctorSkip, Message: fmt.Sprintf(format, args...)}
+}
+
+func warned(remediation string, format string, args ...any) *doctorCheckResult {
+	return &doctorCheckResult{Status: doctorWarn, Message: fmt.Sprintf(format, args...), Remediation: remediation}
+}
+
+func failed(remediation string, format string, args ...any) *doctorCheckResult {
+	return &doctorCheckResult{Status: doctorFail, Message: fmt.Sprintf(format, args...), Remediation: remediation}
+}
+
+// doctorEnvironment is shared by the checks, a check can record facts in it so that the
+// following ones can skip when a pre-requisite is missing.
+type doctorEnvironment struct {
+	global                *GlobalModel
+	goreleaserDockerImage string
+
+	dockerInstalled bool
+	dockerRunning   bool
+	imageFound      bool
+
+	// gitHubClient is nil unless a valid GitHub token was found
+	gitHubClient *github.Client
+}
+
+type doctorCheck struct {
+	name string
+	run  func(env *doctorEnvironment) *doctorCheckResult
+}
+
+// doctorChecks are executed in order, some checks depend on facts recorded by previous ones.
+var doctorChecks = []*doctorCheck{
+	{"Configuration", checkConfiguration},
+	{"Docker installed", checkDockerInstalled},
+	{"Docker running", checkDockerRunning},
+	{"Goreleaser image", checkGoreleaserImage},
+	{"Goreleaser version", checkGoreleaserVersion},
+	{"GitHub CLI", checkGitHubCLI},
+	{"GitHub token", checkGitHubToken},
+	{"Git remote", checkGitRemote},
+	{"Upstream branch", checkUpstreamBranch},
+}
+
+// runDoctorChecks executes all checks printing their result, it returns the number of
+// failed checks.
+func runDoctorChecks(env *doctorEnvironment) (failures int) {
+	for _, check := range doctorChecks {
+		result := check.run(env)
+		zlog.Debug("doctor check completed", zap.String("check", check.name), zap.String("status", string(result.Status)))
+
+		fmt.Printf("[%s] %s: %s\n", result.Status, check.name, result.Message)
+		if result.Status != doctorPass && result.Remediation != "" {
+			fmt.Println()
+			fmt.Println(strings.Join(mapEachLine(result.Remediation, func(line string) string {
+				if line == "" {
+					return line
+				}
+
+				return "       " + line
+			}), "\n"))
+			fmt.Println()
+		}
+
+		if result.Status == doctorFail {
+			failures++
+		}
+	}
+
+	return
+}
+
+func checkConfiguration(env *doctorEnvironment) *doctorCheckResult {
+	if configFileErr != nil {
+		return failed(cli.Dedent(`
+			Fix the '.sfreleaser' file so that it's a valid YAML document, you can also
+			re-generate it with 'sfreleaser init --overwrite'.
+		`), "Unable to load '.sfreleaser' config file: %s", configFileErr)
+	}
+
+	if env.global.ConfigRoot == "" {
+		return warned("Run 'sfreleaser init' at the root of your project to create it.", "No '.sfreleaser' config file found from %q", env.global.WorkingDirectory)
+	}
+
+	var missing []string
+	if env.global.Language == LanguageUnset {
+		missing = append(missing, "'global.language'")
+	}
+
+	if env.global.Variant == VariantUnset {
+		missing = append(missing, "'global.variant'")
+	}
+
+	if len(missing) > 0 {
+		return failed("Define them in the '.sfreleaser' file or run 'sfreleaser init --overwrite' to re-generate it.", "Config file in %q is missing %s", env.global.ConfigRoot, strings.Join(missing, " and "))
+	}
+
+	if content, err := os.ReadFile(viper.ConfigFileUsed()); err == nil {
+		if _, applied, err := migrateConfig(content); err == nil && len(applied) > 0 {
+			var deprecated []string
+			for _, migration := range applied {
+				deprecated = append(deprecated, migration.Description)
+			}
+
+			return warned("Run 'sfreleaser config migrate' to rewrite them into their replacement.", "Config file in %q uses deprecated options: %s", env.global.ConfigRoot, strings.Join(deprecated, ", "))
+		}
+	}
+
+	return passed("Loaded from %q (language %s, variant %s)", env.global.ConfigRoot, env.global.Language, env.global.Variant)
+}
+
+func checkDockerInstalled(env *doctorEnvironment) *doctorCheckResult {
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return failed(dockerMissingMessage, "Unable to find command 'docker'")
+	}
+
+	env.dockerInstalled = true
+	return passed("Found at %s", path)
+}
+
+func checkDockerRunning(env *doctorEnvironment) *doctorCheckResult {
+	if !env.dockerInstalled {
+		return skipped("Docker is not installed")
+	}
+
+	if _, _, err := maybeResultOf("docker info"); err != nil {
+		return failed(dockerNotRunningMessage, "Command 'docker info' failed: %s", err)
+	}
+
+	env.dockerRunning = true
+	return passed("Docker Engine is running")
+}
+
+func checkGoreleaserImage(env *doctorEnvironment) *doctorCheckResult {
+	if !env.dockerRunning {
+		return skipped("Docker is not running")
+	}
+
+	if _, _, err := maybeResultOf("docker image inspect --format '{{.Id}}'", env.goreleaserDockerImage); err != nil {
+		return failed(dedent(`
+			The image is pulled automatically on first release which can take a while, pull it
+			right now with:
+
+			    docker pull %s
+		`, env.goreleaserDockerImage), "Image %q not found locally", env.goreleaserDockerImage)
+	}
+
+	env.imageFound = true
+	return passed("Image %q found locally", env.goreleaserDockerImage)
+}
+
+func checkGoreleaserVersion(env *doctorEnvironment) *doctorCheckResult {
+	if !env.imageFound {
+		return skipped("Image %q not found locally", env.goreleaserDockerImage)
+	}
+
+	output, _, err := maybeResultOf("docker run --rm --entrypoint goreleaser", env.goreleaserDockerImage, "--version")
+	if err != nil {
+		return failed("Ensure the image is based on 'goreleaser/goreleaser-cross', which has 'goreleaser' in its PATH.", "Unable to run 'goreleaser --version' in image %q: %s", env.goreleaserDockerImage, err)
+	}
+
+	return goreleaserVersionResult(env.goreleaserDockerImage, output)
+}
+
+var goreleaserVersionRegex = regexp.MustCompile(`(?i)(?:GitVersion:|goreleaser version)\s*v?([0-9]+\.[0-9]+\.[0-9]+)`)
+
+// goreleaserVersionResult checks that the Goreleaser version reported by 'goreleaser --version'
+// supports the 'version: 2' configuration 'sfreleaser' generates.
+func goreleaserVersionResult(image string, versionOutput string) *doctorCheckResult {
+	groups := goreleaserVersionRegex.FindStringSubmatch(versionOutput)
+	if groups == nil {
+		return warned("", "Unable to find Goreleaser version in 'goreleaser --version' output of image %q", image)
+	}
+
+	version, err := versioning.NewVersion(groups[1])
+	if err != nil {
+		return warned("", "Invalid Goreleaser version %q reported by image %q", groups[1], image)
+	}
+
+	if version.Segments()[0] < 2 {
+		return failed(cli.Dedent(`
+			The generated Goreleaser configuration uses 'version: 2' which requires Goreleaser
+			v2 or later. Update (or remove to use the default) the image in '.sfreleaser':
+
+			    release:
+			      goreleaser-docker-image: goreleaser/goreleaser-cross:v1.25
+
+			If you already use this image, Docker may have cached an older version of it,
+			re-pull it with 'docker pull goreleaser/goreleaser-cross:v1.25'.
+		`), "Goreleaser v%s does not support the v2 configuration generated by 'sfreleaser'", version)
+	}
+
+	return passed("Goreleaser v%s supports the v2 configuration", version)
+}
+
+func checkGitHubCLI(env *doctorEnvironment) *doctorCheckResult {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return skipped("Command 'gh' not installed, it's optional as 'sfreleaser' calls GitHub API directly")
+	}
+
+	if output, _, err := maybeResultOf("gh auth status"); err != nil {
+		zlog.Debug("gh auth status failed", zap.String("output", output))
+		return warned("Run 'gh auth login' to authenticate, it's useful to publish draft releases with 'gh release edit <version> --draft=false'.", "Command 'gh' is not authenticated")
+	}
+
+	return passed("Command 'gh' is authenticated")
+}
+
+func checkGitHubToken(env *doctorEnvironment) *doctorCheckResult {
+	token, from, globalGitHubTokenFile := findGitHubToken()
+	if token == "" {
+		return failed(gitHubTokenMissingMessage(globalGitHubTokenFile), "No GitHub token found")
+	}
+
+	if !githubTokenRegex.MatchString(token) {
+		return failed("Replace it with a valid GitHub personal access token (fine-grained or classic) or GitHub Actions token.", "GitHub token found through %s is malformed, should match %q", from, githubTokenRegex)
+	}
+
+	client := newGitHubClient(token)
+	info, err := client.AuthenticatedTokenInfo(context.Background())
+	if err != nil {
+		return failed("Create a new token on https://github.com/settings/tokens and replace the current one.", "GitHub token found through %s was rejected by GitHub: %s", from, err)
+	}
+
+	env.gitHubClient = client
+	return gitHubTokenScopesResult(from, info)
+}
+
+// gitHubTokenScopesResult checks that a classic token has the scope required to create
+// releases, other kind of tokens do not report their permissions.
+func gitHubTokenScopesResult(from string, info *github.TokenInfo) *doctorCheckResult {
+	if info.Scopes == nil {
+		return passed("Token found through %s is valid for user %q (fine-grained or GitHub Actions token, permissions are not reported)", from, info.User.Login)
+	}
+
+	if !slices.Contains(info.Scopes, "repo") && !slices.Contains(info.Scopes, "public_repo") {
+		return warned(
+			"Releasing requires the 'repo' scope (or 'public_repo' for public repositories only), add it to your token on https://github.com/settings/tokens.",
+			"Token found through %s is valid for user %q but lacks 'repo' scope (scopes: %s)", from, info.User.Login, orNone(info.Scopes),
+		)
+	}
+
+	return passed("Token found through %s is valid for user %q (scopes: %s)", from, info.User.Login, orNone(info.Scopes))
+}
+
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func checkGitRemote(env *doctorEnvironment) *doctorCheckResult {
+	global := env.global
+	expected := global.Owner + "/" + global.Project
+	remote := resolveGitRemote(global)
+
+	output, _, err := maybeResultOf("git remote get-url", remote)
+	if err != nil {
+		return failed(fmt.Sprintf("Add it with 'git remote add %s https://github.com/%s.git'.", remote, expected), "Git remote %q not found", remote)
+	}
+
+	remoteURL := strings.TrimSpace(output)
+	owner, project, found := parseGitHubRemoteURL(remoteURL)
+	if !found {
+		return warned("", "Git remote %q URL %q is not a GitHub repository", remote, remoteURL)
+	}
+
+	if !strings.EqualFold(owner+"/"+project, expected) {
+		return failed(dedent(`
+			Either the '.sfreleaser' owner/project are wrong or the remote points to another
+			repository. Fix the remote with 'git remote set-url %s https://github.com/%s.git'
+			or the 'global.owner'/'global.project' values.
+		`, remote, expected), "Git remote %q points to %s/%s but release is for %s", remote, owner, project, expected)
+	}
+
+	if env.gitHubClient == nil {
+		return passed("Git remote %q points to %s (repository existence not verified, no valid GitHub token)", remote, expected)
+	}
+
+	repository, err := env.gitHubClient.GetRepository(context.Background(), global.Owner, global.Project)
+	if err != nil {
+		if errors.Is(err, github.ErrNotFound) {
+			return failed("Ensure the repository exists and that your GitHub token has access to it.", "Repository %s not found on GitHub", expected)
+		}
+
+		return warned("", "Unable to retrieve repository %s from GitHub: %s", expected, err)
+	}
+
+	if !strings.EqualFold(repository.FullName, expected) {
+		return failed(dedent(`
+			The repository was renamed or transferred, GitHub redirects requests to the new
+			location which leads to '307 Moved Permanently' errors when uploading artifacts.
+			Update the remote with 'git remote set-url %s https://github.com/%s.git' and
+			the 'global.owner'/'global.project' values of '.sfreleaser'.
+		`, remote, repository.FullName), "Repository %s moved to %s", expected, repository.FullName)
+	}
+
+	return passed("Git remote %q points to %s", remote, expected)
+}
+
+var gitHubRemoteURLRegex = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// parseGitHubRemoteURL extracts the owner and project of a GitHub remote URL in SSH
+// ('git@github.com:owner/project.git') or HTTPS ('https://github.com/owner/project') form.
+func parseGitHubRemoteURL(remoteURL string) (owner string, project string, found bool) {
+	groups := gitHubRemoteURLRegex.FindStringSubmatch(remoteURL)
+	if groups == nil {
+		return "", "", false
+	}
+
+	return groups[1], groups[2], true
+}
+
+func checkUpstreamBranch(env *doctorEnvironment) *doctorCheckResult {
+	branch, _, err := maybeResultOf("git rev-parse --abbrev-ref HEAD")
+	if err != nil {
+		return failed("Run 'sfreleaser' from within a Git repository.", "Unable to determine current branch: %s", strings.TrimSpace(branch))
+	}
+
+	branch = strings.TrimSpace(branch)
+	upstream, _, err := maybeResultOf("git rev-parse --abbrev-ref --symbolic-full-name '@{u}'")
+	if err != nil {
+		return failed(
+			fmt.Sprintf("Push the branch and track it with 'git push --set-upstream %s %s'.", resolveGitRemote(env.global), branch),
+			"Branch %q has no upstream branch, 'sfreleaser release' cannot check it's in sync with remote", branch,
+		)
+	}
+
+	return passed("Branch %q tracks %q", branch, strings.TrimSpace(upstream))
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/streamingfast/sfreleaser/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_goreleaserVersionResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   doctorStatus
+	}{
+		{"v2 build info", "  ____       ____      _\nGitVersion:    v2.4.8\nGitCommit:     abc\n", doctorPass},
+		{"v1 short output", "goreleaser version 1.26.2\ncommit: abc\n", doctorFail},
+		{"unknown output", "command not found\n", doctorWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, goreleaserVersionResult("goreleaser/goreleaser-cross:v1.25", tt.output).Status)
+		})
+	}
+}
+
+func Test_gitHubTokenScopesResult(t *testing.T) {
+	user := &github.User{Login: "octocat"}
+
+	tests := []struct {
+		name   string
+		scopes []string
+		want   doctorStatus
+	}{
+		{"fine-grained token", nil, doctorPass},
+		{"classic with repo", []string{"repo", "workflow"}, doctorPass},
+		{"classic with public_repo", []string{"public_repo"}, doctorPass},
+		{"classic without repo", []string{"read:org"}, doctorWarn},
+		{"classic without scopes", []string{}, doctorWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gitHubTokenScopesResult("environment variable GITHUB_TOKEN", &github.TokenInfo{User: user, Scopes: tt.scopes}).Status)
+		})
+	}
+}
+
+func Test_parseGitHubRemoteURL(t *testing.T) {
+	tests := []struct {
+		remoteURL   string
+		wantOwner   string
+		wantProject string
+		wantFound   bool
+	}{
+		{"git@github.com:streamingfast/sfreleaser.git", "streamingfast", "sfreleaser", true},
+		{"https://github.com/streamingfast/sfreleaser", "streamingfast", "sfreleaser", true},
+		{"https://github.com/streamingfast/sfreleaser.git", "streamingfast", "sfreleaser", true},
+		{"ssh://git@github.com/streamingfast/firehose-core.git", "streamingfast", "firehose-core", true},
+		{"https://gitlab.com/streamingfast/sfreleaser.git", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteURL, func(t *testing.T) {
+			owner, project, found := parseGitHubRemoteURL(tt.remoteURL)
+			assert.Equal(t, tt.wantOwner, owner)
+			assert.Equal(t, tt.wantProject, project)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
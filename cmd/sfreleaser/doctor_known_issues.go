@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/streamingfast/cli"
+)
+
+// knownIssue is a failure we know how to fix, it's identified by the error text it produces.
+type knownIssue struct {
+	// Title is the typical error message of the issue
+	Title string
+	// Patterns identify the issue in a log, the issue matches if any of them matches
+	Patterns []*regexp.Regexp
+	Solution string
+}
+
+func (i *knownIssue) String() string {
+	return fmt.Sprintf("##\n### %s\n##\n\n%s", i.Title, i.Solution)
+}
+
+var knownIssues = []*knownIssue{
+	{
+		Title:    "X release failed after 0s error=yaml: unmarshal errors: line X: field ids not found in type config.Archive (or 'formats' or 'version_template')",
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`field (?:ids|formats|version_template) not found in type config\.`)},
+		Solution: cli.Dedent(`
+			This error indicates that you are using an outdated version of 'sfreleaser' that generates Goreleaser configuration
+			files with deprecated field names. The Goreleaser v2 configuration has changed some field names:
+
+			- 'archives.ids' is now 'archives.builds'
+			- 'archives.formats' (plural) is now 'archives.format' (singular)
+			- 'snapshot.version_template' is now 'snapshot.name_template'
+
+			**Solution:** Update to the latest version of 'sfreleaser' which generates the correct configuration format.
+			You can update by running:
+
+				brew upgrade sfreleaser
+
+			Or by downloading the latest release from GitHub and replacing your binary. After updating, the tool will
+			generate configurations compatible with the latest Goreleaser versions.
+
+			Additionally, check your project's '.sfreleaser' config file for any custom Docker image version:
+
+				release:
+					goreleaser-docker-image: <something>
+
+			If you have an older image version specified (like 'goreleaser-cross:v1.23' or earlier), update it to
+			'goreleaser/goreleaser-cross:v1.25' or later, or remove the setting entirely to use the default.
+		`),
+	},
+	{
+		Title:    "X release failed after 0s error=only configurations files on  version: 1  are supported, yours is  version: 2 , please update your configuration",
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`only configurations files on\s+version: 1\s+are supported`)},
+		Solution: cli.Dedent(`
+			This happens when you are using a newer 'sfreleaser' version (>= v0.8.0) but that the Docker image 'goreleaser/goreleaser-cross' used for the
+			building is too old. Indeed, newer versions of 'goreleaser-cross' uses Goreleaser version > 2.0 which brings a bunch of breaking changes.
+
+			The 'sfreleaser' tool only works with Goreleaser version >= 2.0.0. First check the project's '.sfreleaser', if there is
+
+				release:
+					goreleaser-docker-image: <something>
+
+			Ensure the image is based on 'goreleaser-cross:v1.25' or later. If you were using a custom image,
+			update it the base to use 'goreleaser/goreleaser-cross:v1.25' or later.
+
+			If you are not using a custom image and still have the problem, you might need to re-pull the
+			image, as Docker may have cached an older version. This can be done with the following command:
+
+				docker pull --platform=linux/arm64 goreleaser/goreleaser-cross:v1.25
+
+			> **Note**
+			> Change --platform=linux/arm64 to your platform if you are not on ARM64.
+		`),
+	},
+	{
+		Title:    "scm releases: failed to publish artifacts: could not release: POST https://api.github.com/repos/streamingfast/substreams-ethereum/releases: 422 Validation Failed [{Resource:Release Field:target_commitish Code:invalid Message:}]",
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`422 Validation Failed.*target_commitish`)},
+		Solution: cli.Dedent(`
+			It seems on fast build, there is race condition on GitHub side where the commit
+			does not exists yet when we try to create the release (at least it's not visible yet
+			to GitHub Releaser service).
+
+			The 'sfreleaser release' command detects this error and automatically retries with an
+			exponential backoff, waiting before each retry for GitHub to report the pushed commit. If
+			you still get the error, it means all retries failed.
+
+			Push manually the commit you want to release and re-run the command (use '--resume' to
+			skip already completed steps).
+
+			If this doesn't work, check if GitHub is having issues with their API, you can check their
+			status at https://www.githubstatus.com/. Sometimes there is no downtime status but
+			GitHub is slow leading to the error repeating more often.
+
+			Wait a bit and retry later. If the issue persist, the is probably something bigger
+			going on.
+		`),
+	},
+	{
+		Title:    "Unable to copy command PTY to stdout: read /dev/ptmx: input/output error",
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`/dev/ptmx: input/output error`)},
+		Solution: cli.Dedent(`
+			If you have some error related to PTY, it's possible we have a problem running the command inside
+			an internal PTY. That is done like this so that executed commands thinks that are in a standard
+			terminal and as such, render colors and other things correctly.
+
+			If you have such error, the best course of action is to disable PTY by setting the following
+			environment variable: SFRELEASER_DISABLE_PTY=true.
+
+			Doing so will run the command using a non-PTY terminal, rendering will be different but it
+			everything should work correctly.
+		`),
+	},
+	{
+		Title:    "homebrew tap formula: failed to publish artifacts: PUT https://api.github.com/repos/<owner>/<tap-repo>/<...>: 404 Not Found",
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`(?i)homebrew tap formula.*\b40[34] (?:Not Found|Forbidden)`)},
+		Solution: cli.Dedent(`
+			This happens because you are trying to publish a new version of a formula and the configured
+			tap owner/repo does not exists or you don't have access to (if error code is 403 for example).
+
+			You can fix this by creating the tap repository on GitHub and making sure you have access to it.
+			You can use:
+
+			    release:
+			        brew-tap-repo: <repo>
+
+			To define the repository that is going to hold the tap formula. You can also completely disable
+			brew publishing with:
+
+			    release:
+			        brew-disabled: true
+		`),
+	},
+	{
+		Title:    "Failed to upload artifact <...> https://uploads.github.com/repos/<org>/<repo>/releases/<resource>: 307 Moved Permanently",
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`\b307 (?:Moved Permanently|Temporary Redirect)`)},
+		Solution: cli.Dedent(`
+			This usually happens when the GitHub repository was renamed or transferred to another
+			organization. The <org>/<repo> value in the URL is then the old location of the
+			repository. While sfreleaser's own GitHub API calls follow redirects, Goreleaser uploads
+			the release artifacts to the repository defined by 'owner' and 'project' and fails on the
+			redirect to the new location.
+
+			To fix this, update 'owner' and 'project' (or 'repository') under 'global' section of
+			your '.sfreleaser' file to the new location of the repository. Also update your Git
+			remote if it still points at the old location: 'git remote set-url origin <new-url>'.
+		`),
+	},
+}
+
+type knownIssueMatch struct {
+	Issue *knownIssue
+	// Line is the first line of the log that matched the issue
+	Line string
+}
+
+// ansiEscapeRegex matches terminal escape sequences (colors mostly) that tools write when
+// executed through a PTY, they are stripped before matching.
+var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// matchKnownIssues returns the known issues found in `log`, in registry order, each issue
+// appears once even if it's found multiple times.
+func matchKnownIssues(log string) (matches []*knownIssueMatch) {
+	lines := strings.Split(ansiEscapeRegex.ReplaceAllString(log, ""), "\n")
+
+	for _, issue := range knownIssues {
+		if line, found := findKnownIssueLine(issue, lines); found {
+			matches = append(matches, &knownIssueMatch{Issue: issue, Line: line})
+		}
+	}
+
+	return
+}
+
+func findKnownIssueLine(issue *knownIssue, lines []string) (string, bool) {
+	for _, line := range lines {
+		for _, pattern := range issue.Patterns {
+			if pattern.MatchString(line) {
+				return strings.TrimSpace(strings.TrimSuffix(line, "\r")), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// printKnownIssuesDiagnosis prints the solution of the known issues found in `log` and returns
+// true if there was at least one.
+func printKnownIssuesDiagnosis(log string) bool {
+	matches := matchKnownIssues(log)
+	if len(matches) == 0 {
+		return false
+	}
+
+	fmt.Printf("Found %d known issue(s) matching the failure:\n", len(matches))
+	for _, match := range matches {
+		fmt.Println()
+		fmt.Printf("> %s\n", match.Line)
+		fmt.Println()
+		fmt.Println(match.Issue)
+	}
+
+	return true
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_knownIssues_MatchOwnTitle(t *testing.T) {
+	for _, issue := range knownIssues {
+		t.Run(issue.Title, func(t *testing.T) {
+			matches := matchKnownIssues(issue.Title)
+			require.Len(t, matches, 1)
+			assert.Same(t, issue, matches[0].Issue)
+		})
+	}
+}
+
+func Test_matchKnownIssues(t *testing.T) {
+	log := dedent(`
+		  • starting release...
+		  • loading                                          path=build/goreleaser.yaml
+		` + "\x1b[31m" + `  ⨯ release failed after 12s                       error=scm releases: failed to publish artifacts: could not release: POST https://api.github.com/repos/streamingfast/sfreleaser/releases: 422 Validation Failed [{Resource:Release Field:target_commitish Code:invalid Message:}]` + "\x1b[0m" + `
+		Unable to copy command PTY to stdout: read /dev/ptmx: input/output error
+		Unable to copy command PTY to stdout: read /dev/ptmx: input/output error
+	`)
+
+	matches := matchKnownIssues(log)
+	require.Len(t, matches, 2)
+
+	assert.Contains(t, matches[0].Issue.Title, "target_commitish")
+	assert.Equal(t, "⨯ release failed after 12s                       error=scm releases: failed to publish artifacts: could not release: POST https://api.github.com/repos/streamingfast/sfreleaser/releases: 422 Validation Failed [{Resource:Release Field:target_commitish Code:invalid Message:}]", matches[0].Line)
+
+	assert.Contains(t, matches[1].Issue.Title, "/dev/ptmx")
+
+	assert.Empty(t, matchKnownIssues("  • release succeeded after 42s\n"))
+}
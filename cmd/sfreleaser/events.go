@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+)
+
+// activeEvents is non-nil when running with '--output json', in which case the progress of the
+// run is emitted as newline-delimited JSON events, see [emitEvent].
+var activeEvents *eventEmitter
+
+type eventType string
+
+const (
+	eventStarted      eventType = "started"
+	eventStepStarted  eventType = "step_started"
+	eventStepFinished eventType = "step_finished"
+	eventStepSkipped  eventType = "step_skipped"
+	eventCommand      eventType = "command"
+	eventAssetUpload  eventType = "asset_uploaded"
+	eventReleaseURL   eventType = "release_url"
+	eventCompleted    eventType = "completed"
+	eventError        eventType = "error"
+)
+
+type outputEvent struct {
+	Time       time.Time `json:"time"`
+	Type       eventType `json:"type"`
+	Repository string    `json:"repository,omitempty"`
+	Version    string    `json:"version,omitempty"`
+	Step       string    `json:"step,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Success    *bool     `json:"success,omitempty"`
+	Asset      string    `json:"asset,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Published  *bool     `json:"published,omitempty"`
+	Message    string    `json:"message,omitempty"`
+}
+
+type eventEmitter struct {
+	lock   sync.Mutex
+	writer io.Writer
+	// step is the step currently executing, it's attached to the error event if the run fails in it
+	step string
+}
+
+func newEventEmitter(writer io.Writer) *eventEmitter {
+	return &eventEmitter{writer: writer}
+}
+
+// mustSetupOutput configures the output mode from flag '--output'. In 'json' mode, standard output
+// is reserved for events, every other output (including the output of executed tools) is then
+// redirected to standard error.
+func mustSetupOutput(cmd *cobra.Command) {
+	switch output := sflags.MustGetString(cmd, "output"); output {
+	case "text":
+	case "json":
+		activeEvents = newEventEmitter(os.Stdout)
+		os.Stdout = os.Stderr
+	default:
+		cli.Quit("Invalid output %q, accepted values are 'text' or 'json'", output)
+	}
+}
+
+// emitEvent writes `event` as a single JSON line if events are enabled, it's a no-op otherwise.
+func emitEvent(event *outputEvent) {
+	if activeEvents == nil {
+		return
+	}
+
+	activeEvents.emit(event)
+}
+
+func (e *eventEmitter) emit(event *outputEvent) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	if event.Type == eventError && event.Step == "" {
+		event.Step = e.step
+	}
+
+	content, err := json.Marshal(event)
+	if err != nil {
+		// Cannot really happen, all fields are serializable
+		panic(fmt.Errorf("marshal event: %w", err))
+	}
+
+	fmt.Fprintf(e.writer, "%s\n", content)
+}
+
+// trackStep executes `fn` emitting a start and finish event for `step` around it.
+func trackStep(step string, fn func()) {
+	if activeEvents == nil {
+		fn()
+		return
+	}
+
+	startTime := time.Now()
+	emitEvent(&outputEvent{Type: eventStepStarted, Step: step})
+
+	activeEvents.lock.Lock()
+	activeEvents.step = step
+	activeEvents.lock.Unlock()
+
+	fn()
+
+	activeEvents.lock.Lock()
+	activeEvents.step = ""
+	activeEvents.lock.Unlock()
+
+	emitEvent(&outputEvent{Type: eventStepFinished, Step: step, DurationMs: time.Since(startTime).Milliseconds()})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_trackStep(t *testing.T) {
+	output := bytes.NewBuffer(nil)
+	activeEvents = newEventEmitter(output)
+	t.Cleanup(func() { activeEvents = nil })
+
+	trackStep("tag", func() {
+		emitEvent(&outputEvent{Type: eventCommand, Command: "git tag v1.0.0", Success: ptr(true)})
+	})
+
+	trackStep("goreleaser-release", func() {
+		emitEvent(&outputEvent{Type: eventError, Message: "goreleaser failed"})
+	})
+
+	var events []*outputEvent
+	for _, line := range getLines(output.String()) {
+		event := &outputEvent{}
+		require.NoError(t, json.Unmarshal([]byte(line), event))
+		assert.False(t, event.Time.IsZero())
+
+		events = append(events, event)
+	}
+
+	require.Len(t, events, 6)
+
+	var types []eventType
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+
+	assert.Equal(t, []eventType{eventStepStarted, eventCommand, eventStepFinished, eventStepStarted, eventError, eventStepFinished}, types)
+	assert.Equal(t, "tag", events[0].Step)
+	assert.Equal(t, "git tag v1.0.0", events[1].Command)
+	assert.Equal(t, ptr(true), events[1].Success)
+	assert.Equal(t, "goreleaser-release", events[4].Step, "error event should be attributed to the running step")
+	assert.Equal(t, "goreleaser failed", events[4].Message)
+}
+
+func Test_emitEvent_Disabled(t *testing.T) {
+	require.Nil(t, activeEvents)
+
+	called := false
+	trackStep("tag", func() { called = true })
+	emitEvent(&outputEvent{Type: eventStarted})
+
+	assert.True(t, called)
+}
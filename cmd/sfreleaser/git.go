@@ -71,14 +71,21 @@ func resolveGitRemote(global *GlobalModel) string {
 	return global.GitRemote
 }
 
-func ensureGitSync(global *GlobalModel) {
+func ensureGitSync(global *GlobalModel, gitPull bool) {
+	if isGitDetachedHead() {
+		// There is no branch to sync, happens when the pushed tag is checked out like in the
+		// workflow written by 'sfreleaser init --with-workflow'
+		fmt.Println("Git HEAD is detached (not on a branch), skipping Git sync check")
+		return
+	}
+
 	state := fetchGitSyncState()
 
 	switch state {
 	case gitSyncUpToDate:
 
 	case gitSyncNeedPull:
-		if yes, _ := cli.PromptConfirm("It seems you need to 'git pull', do it now?"); yes {
+		if confirm("It seems you need to 'git pull', do it now?", "git-pull", gitPull) {
 			run("git pull", resolveGitRemote(global))
 		}
 
@@ -146,28 +153,75 @@ func ensureGitNotDirty() {
 	}
 }
 
+func isGitDetachedHead() bool {
+	_, _, err := maybeResultOf("git symbolic-ref -q HEAD")
+	return err != nil
+}
+
+// gitTagPointsAtHead returns true if `tag` exists locally and points to the current commit.
+func gitTagPointsAtHead(tag string) bool {
+	tagCommit, _, err := maybeResultOf("git rev-parse --verify --quiet", tag+"^{commit}")
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSpace(tagCommit) == strings.TrimSpace(resultOf("git rev-parse HEAD"))
+}
+
 func isGitDirty() bool {
 	return resultOf("git status --porcelain") != ""
 }
 
-var remoteTagRegex = regexp.MustCompile(`refs/tags/(v?[0-9]+\.[0-9]+\.[0-9]+[^\s]*)`)
-
-func latestTag(remote string) (latestTag string) {
+// latestTag returns the most recent version tag (including `tagPrefix`) of `remote`, empty
+// if the project was never released.
+func latestTag(remote string, tagPrefix string) (latestTag string) {
 	defer func() {
-		zlog.Debug("latest tag", zap.String("found", latestTag))
+		zlog.Debug("latest tag", zap.String("found", latestTag), zap.String("prefix", tagPrefix))
 	}()
 
 	// We use `maybeResultOf` but ignore error so no error is printed
-	output, _, _ := maybeResultOf("git -c 'versionsort.suffix=-' ls-remote --exit-code --refs --sort='version:refname' --tags", remote, "'*.*.*'")
+	output, _, _ := maybeResultOf("git -c 'versionsort.suffix=-' ls-remote --exit-code --refs --sort='version:refname' --tags", remote, "'"+tagPrefix+"*.*.*'")
+
+	return latestTagFromRemoteRefs(output, tagPrefix)
+}
+
+// latestTagFromRemoteRefs returns the last tag of the version sorted 'git ls-remote' `output`
+// that is `tagPrefix` followed by a version. The pattern given to 'git ls-remote' also matches
+// tags of other prefixes (e.g. 'other/v1.0.0' for '*.*.*') which are ignored.
+func latestTagFromRemoteRefs(output string, tagPrefix string) string {
+	tagRegex := regexp.MustCompile(`refs/tags/(` + regexp.QuoteMeta(tagPrefix) + `v?[0-9]+\.[0-9]+\.[0-9]+[^\s/]*)$`)
 
 	lines := getLines(output)
-	if len(lines) == 0 {
-		return ""
+	for i := len(lines) - 1; i >= 0; i-- {
+		if groups := tagRegex.FindStringSubmatch(strings.TrimSpace(lines[i])); len(groups) > 1 {
+			return groups[1]
+		}
+	}
+
+	return ""
+}
+
+// remoteTags returns the tags of `remote` matching the glob `pattern`, empty if the remote
+// cannot be listed.
+func remoteTags(remote string, pattern string) (tags []string) {
+	// We use `maybeResultOf` but ignore error so no error is printed
+	output, _, _ := maybeResultOf("git ls-remote --refs --tags", remote, "'"+pattern+"'")
+
+	for _, line := range getLines(output) {
+		if _, tag, found := strings.Cut(strings.TrimSpace(line), "refs/tags/"); found {
+			tags = append(tags, tag)
+		}
 	}
 
-	lastLine := lines[len(lines)-1]
-	groups := remoteTagRegex.FindStringSubmatch(lastLine)
-	cli.Ensure(len(groups) > 1, "Unable to extract tag regex from line %q", lastLine)
+	return tags
+}
+
+// gitTopLevelDirectory returns the root directory of the Git repository containing `directory`.
+func gitTopLevelDirectory(directory string) (string, error) {
+	output, info, err := maybeResultOf("git -C", directory, "rev-parse --show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("command %q failed: %s", info, strings.TrimSpace(output))
+	}
 
-	return groups[1]
+	return strings.TrimSpace(output), nil
 }
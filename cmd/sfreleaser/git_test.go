@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_latestTagFromRemoteRefs(t *testing.T) {
+	refs := dedent(`
+		1111111111111111111111111111111111111111	refs/tags/v1.0.0
+		2222222222222222222222222222222222222222	refs/tags/firehose-ethereum/v1.2.0
+		3333333333333333333333333333333333333333	refs/tags/firehose-ethereum/v1.3.0-rc.1
+		4444444444444444444444444444444444444444	refs/tags/v1.1.0
+		5555555555555555555555555555555555555555	refs/tags/firehose-near/v2.0.0
+	`)
+
+	tests := []struct {
+		name      string
+		output    string
+		tagPrefix string
+		want      string
+	}{
+		{"no tags", "", "", ""},
+		{"no prefix ignores prefixed tags", refs, "", "v1.1.0"},
+		{"prefix", refs, "firehose-ethereum/", "firehose-ethereum/v1.3.0-rc.1"},
+		{"other prefix", refs, "firehose-near/", "firehose-near/v2.0.0"},
+		{"prefix never released", refs, "firehose-solana/", ""},
+		{"prefix is not a pattern", "1111111111111111111111111111111111111111	refs/tags/fooxbar-v1.0.0", "foo.bar-", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, latestTagFromRemoteRefs(tt.output, tt.tagPrefix))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/streamingfast/cli"
+	"github.com/streamingfast/sfreleaser/github"
 	"go.uber.org/zap"
 )
 
@@ -19,76 +22,105 @@ var (
 	ghpPersonalLegacyTokenRegex = `ghp_[a-zA-Z0-9]{36}`
 	ghPersonalTokenRegex        = `github_pat_[a-zA-Z0-9]{22}_[a-zA-Z0-9]{59}`
 	ghActionTokenRegex          = `v[0-9]\.[0-9a-f]{40}`
+	// The 'GITHUB_TOKEN' of GitHub Actions, used by the workflow written by 'sfreleaser init --with-workflow'
+	ghsActionTokenRegex = `ghs_[a-zA-Z0-9]{36}`
 )
 
-var githubTokenRegex = regexp.MustCompile(fmt.Sprintf(`^\s*(%s|%s|%s)\s*$`, ghpPersonalLegacyTokenRegex, ghPersonalTokenRegex, ghActionTokenRegex))
+var githubTokenRegex = regexp.MustCompile(fmt.Sprintf(`^\s*(%s|%s|%s|%s)\s*$`, ghpPersonalLegacyTokenRegex, ghPersonalTokenRegex, ghActionTokenRegex, ghsActionTokenRegex))
 
-func configureGitHubTokenEnvFile(releaseEnvFile string) {
-	zlog.Debug("verifying github token")
-	globalGitHubTokenFile := filepath.Join(cli.UserHomeDirectory(), ".config", "goreleaser", "github_token")
-
-	from := `"<Not Found>"`
-	token := strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
-
-	if token != "" {
-		from = "environment variable GITHUB_TOKEN"
-	} else if token == "" && cli.FileExists(globalGitHubTokenFile) {
-		from = fmt.Sprintf("global config file %q", globalGitHubTokenFile)
-		token = strings.TrimSpace(cli.ReadFile(globalGitHubTokenFile))
-	}
-
-	zlog.Debug("completed scan for GitHub token", zap.Bool("found", token != ""), zap.String("from", globalGitHubTokenFile))
+// configureGitHubTokenEnvFile finds the GitHub token, writes it to the env file used by
+// Goreleaser and returns it so it can be used to perform GitHub API calls.
+func configureGitHubTokenEnvFile(releaseEnvFile string) (token string) {
+	token, from, globalGitHubTokenFile := findGitHubToken()
 
 	if token != "" {
 		cli.Ensure(githubTokenRegex.MatchString(token), "GitHub token found through %s is invalid, should match %q", from, githubTokenRegex)
 		cli.WriteFile(releaseEnvFile, "GITHUB_TOKEN=%s", token)
 	} else {
-		message := fmt.Sprintf(dedent(`
-			A valid GitHub token is required to perform the release and we couldn't find
-			one through via:
+		cli.Quit("%s", gitHubTokenMissingMessage(globalGitHubTokenFile))
+	}
+
+	return token
+}
 
-			- A global config file %q
-			- A GITHUB_TOKEN environment variable
+func gitHubTokenMissingMessage(globalGitHubTokenFile string) string {
+	return dedent(`
+		A valid GitHub token is required to perform the release and we couldn't find
+		one through via:
 
-			You will need to create your own GitHub Token on GitHub website and make it available through
-			the one of the method mentioned above.
+		- A global config file %q
+		- A GITHUB_TOKEN environment variable
 
-			If you desire to use global config file %q,
-			put the following content in to it:
+		You will need to create your own GitHub Token on GitHub website and make it available through
+		the one of the method mentioned above.
 
-			GITHUB_TOKEN=<github_token>
+		If you desire to use global config file %q,
+		put the following content in to it:
 
-			If you desire to use environment variable GITHUB_TOKEN, export
-			it like:
+		GITHUB_TOKEN=<github_token>
 
-			export GITHUB_TOKEN=<github_token>
+		If you desire to use environment variable GITHUB_TOKEN, export
+		it like:
 
-			The '<github_token>' value must be a valid GitHub personal access token
-			either fine-grained or classic, or a valid GitHub actions token.
-		`), globalGitHubTokenFile, globalGitHubTokenFile)
-		cli.Quit("%s", message)
+		export GITHUB_TOKEN=<github_token>
+
+		The '<github_token>' value must be a valid GitHub personal access token
+		either fine-grained or classic, or a valid GitHub actions token.
+	`, globalGitHubTokenFile, globalGitHubTokenFile)
+}
+
+// findGitHubToken looks for the GitHub token in environment variable GITHUB_TOKEN first
+// and then in the global Goreleaser config file. The returned `from` describes where
+// the token was found.
+func findGitHubToken() (token string, from string, globalGitHubTokenFile string) {
+	zlog.Debug("verifying github token")
+	globalGitHubTokenFile = filepath.Join(cli.UserHomeDirectory(), ".config", "goreleaser", "github_token")
+
+	from = `"<Not Found>"`
+	token = strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
+
+	if token != "" {
+		from = "environment variable GITHUB_TOKEN"
+	} else if token == "" && cli.FileExists(globalGitHubTokenFile) {
+		from = fmt.Sprintf("global config file %q", globalGitHubTokenFile)
+		token = strings.TrimSpace(cli.ReadFile(globalGitHubTokenFile))
 	}
+
+	zlog.Debug("completed scan for GitHub token", zap.Bool("found", token != ""), zap.String("from", globalGitHubTokenFile))
+	return token, from, globalGitHubTokenFile
 }
 
-func releaseURL(global *GlobalModel, version string) string {
-	return strings.TrimSpace(resultOf("gh", "release", "view", version, "--repo", global.Owner+"/"+global.Project, "--json", "url", "-q", ".url"))
+func newGitHubClient(token string) *github.Client {
+	return github.NewClient(token)
 }
 
-func ensureGitHubReleaseValid(global *GlobalModel, version string) {
-	state, url := releaseState(global, version)
+func releaseURL(client *github.Client, global *GlobalModel, version string) string {
+	if isDryRun() {
+		// The release does not exist in dry-run mode, so we cannot query it
+		return fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", global.Owner, global.Project, global.Tag(version))
+	}
+
+	release, err := client.FindRelease(context.Background(), global.Owner, global.Project, global.Tag(version))
+	cli.NoError(err, "Unable to retrieve release %q", version)
+
+	return release.HTMLURL
+}
+
+func ensureGitHubReleaseValid(client *github.Client, global *GlobalModel, version string, deleteExistingDraft bool) {
+	state, release := releaseState(client, global, version)
 
 	switch state {
 	case ghReleaseNotFound:
 		return
 
 	case ghReleaseExists:
-		fmt.Printf("A release for %q already exists at %q\n", version, url)
+		fmt.Printf("A release for %q already exists at %q\n", version, release.HTMLURL)
 		cli.Quit("Refusing to continue since an existing release for this version already exists")
 
 	case ghReleaseDraft:
-		fmt.Printf("A draft release for %q already exists at %s\n", version, url)
-		if yes, _ := cli.PromptConfirm("Would you like to delete this existing draft release?"); yes {
-			deleteExistingRelease(global, version)
+		fmt.Printf("A draft release for %q already exists at %s\n", version, release.HTMLURL)
+		if confirm("Would you like to delete this existing draft release?", "delete-existing-draft", deleteExistingDraft) {
+			deleteExistingRelease(client, global, release)
 			fmt.Println()
 		} else {
 			cli.Quit("Refusing to continue since an existing draft release for this version already exists")
@@ -104,53 +136,102 @@ var (
 	ghReleaseDraft    ghReleaseState = "draft"
 )
 
-func releaseState(global *GlobalModel, version string) (state ghReleaseState, url string) {
-	url, info, err := maybeResultOf("gh release view", "'"+version+"'", "--repo", global.Owner+"/"+global.Project, "--json url -q .url")
-	url = strings.TrimSpace(url)
-
+func releaseState(client *github.Client, global *GlobalModel, version string) (state ghReleaseState, release *github.Release) {
+	release, err := client.FindRelease(context.Background(), global.Owner, global.Project, global.Tag(version))
 	if err != nil {
-		if strings.Contains(url, "release not found") {
-			return ghReleaseNotFound, ""
+		if errors.Is(err, github.ErrNotFound) {
+			return ghReleaseNotFound, nil
 		}
 
-		cli.NoError(err, "Command %q failed with %q", info, url)
+		cli.NoError(err, "Unable to retrieve release %q state", version)
+	}
+
+	if release.Draft {
+		return ghReleaseDraft, release
 	}
 
-	if strings.Contains(url, "releases/tag/untagged") {
-		return ghReleaseDraft, url
+	return ghReleaseExists, release
+}
+
+func deleteExistingRelease(client *github.Client, global *GlobalModel, release *github.Release) {
+	if isDryRun() {
+		activePlan.Step("Delete draft release %q of %s/%s (GitHub API)", release.TagName, global.Owner, global.Project)
+		return
 	}
 
-	return ghReleaseExists, url
+	fmt.Printf("Deleting draft release %q\n", release.TagName)
+	cli.NoError(client.DeleteRelease(context.Background(), global.Owner, global.Project, release.ID), "Unable to delete release %q", release.TagName)
 }
 
-func deleteExistingRelease(global *GlobalModel, version string) {
-	run("gh release delete --yes", "--repo", global.Owner+"/"+global.Project, version)
+func uploadReleaseAsset(client *github.Client, global *GlobalModel, version string, assetPath string) {
+	if isDryRun() {
+		activePlan.Step("Upload asset %q to release %q of %s/%s (GitHub API)", assetPath, version, global.Owner, global.Project)
+		return
+	}
+
+	release, err := client.FindRelease(context.Background(), global.Owner, global.Project, global.Tag(version))
+	cli.NoError(err, "Unable to retrieve release %q", version)
+
+	_, err = client.UploadReleaseAsset(context.Background(), global.Owner, global.Project, release, assetPath)
+	cli.NoError(err, "Unable to upload asset %q to release %q", assetPath, version)
+
+	emitEvent(&outputEvent{Type: eventAssetUpload, Version: version, Asset: filepath.Base(assetPath)})
+}
+
+func publishRelease(client *github.Client, global *GlobalModel, version string, prerelease bool) {
+	if isDryRun() {
+		if prerelease {
+			activePlan.Step("Publish pre-release %q of %s/%s, not marked as latest (GitHub API)", version, global.Owner, global.Project)
+		} else {
+			activePlan.Step("Publish release %q of %s/%s (GitHub API)", version, global.Owner, global.Project)
+		}
+
+		return
+	}
+
+	release, err := client.FindRelease(context.Background(), global.Owner, global.Project, global.Tag(version))
+	cli.NoError(err, "Unable to retrieve release %q", version)
+
+	edit := &github.ReleaseEdit{Draft: ptr(false)}
+	if prerelease {
+		// A pre-release must never become the latest release of the repository
+		edit.Prerelease = ptr(true)
+		edit.MakeLatest = "false"
+	}
+
+	_, err = client.EditRelease(context.Background(), global.Owner, global.Project, release.ID, edit)
+	cli.NoError(err, "Unable to publish release %q", version)
 }
 
-func publishReleaseNow(global *GlobalModel, release *ReleaseModel) {
+func publishReleaseNow(client *github.Client, global *GlobalModel, release *ReleaseModel, progress *releaseProgress) {
 	if global.Language == LanguageRust {
 		switch global.Variant {
 		case VariantSubstreams:
-			fmt.Println("Publishing Substreams package to registry")
-			releaseSubstreamsPublishPackage(release.Substreams)
+			progress.Run(releaseStepSubstreamsPublish, func() {
+				fmt.Println("Publishing Substreams package to registry")
+				releaseSubstreamsPublishPackage(release.Substreams)
+			})
 		default:
 			fmt.Println("Publishing Rust crates")
-			releaseRustPublishCrates(release.Rust)
+			releaseRustPublishCrates(release.Rust, progress)
 		}
 	}
 
 	version := release.Version
 
-	fmt.Println("Publishing release right now")
-	runSilent("gh release edit", version, "--repo", global.Owner+"/"+global.Project, "--draft=false")
+	progress.Run(releaseStepPublish, func() {
+		fmt.Println("Publishing release right now")
+		publishRelease(client, global, version, release.Prerelease)
+	})
 
 	// We re-fetch the releaseURL here because it changed from before publish
-	fmt.Printf("Release published at %s\n", releaseURL(global, version))
+	fmt.Printf("Release published at %s\n", releaseURL(client, global, version))
 
 	cli.ExitHandler(deleteTagExitHandlerID, nil)
 
 	zlog.Debug("refreshing git tags now that release happened")
-	runSilent(fmt.Sprintf(`git fetch %s +refs/tags/%s:refs/tags/%s`, resolveGitRemote(global), version, version))
+	tag := global.Tag(version)
+	runSilent(fmt.Sprintf(`git fetch %s +refs/tags/%s:refs/tags/%s`, resolveGitRemote(global), tag, tag))
 }
 
 func reviewRelease(releaseURL string) {
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// releaseHook is a command executed at some point of the build or release, it's either
+// configured as a plain command string or as a mapping holding the command and its options:
+//
+//	pre-build-hooks:
+//	- make generate
+//	- command: ./scripts/package.sh {{ .release.Version }}
+//	  working-dir: scripts
+//	  env: ["PROFILE=release"]
+//	  timeout: 5m
+//	  continue-on-error: true
+//	  only-for:
+//	    language: rust
+//	    variant: [library, substreams]
+type releaseHook struct {
+	Command string `yaml:"command"`
+	// WorkingDir is relative to the project's working directory, the current one when empty
+	WorkingDir string `yaml:"working-dir"`
+	// Env are 'KEY=value' entries added to the hook's environment, a list is used instead of a
+	// mapping because Viper lower cases mapping keys.
+	Env             []string       `yaml:"env"`
+	Timeout         time.Duration  `yaml:"timeout"`
+	ContinueOnError bool           `yaml:"continue-on-error"`
+	OnlyFor         *hookCondition `yaml:"only-for"`
+}
+
+// hookCondition restricts a hook to some languages and/or variants, an empty list
+// matching all of them.
+type hookCondition struct {
+	Language stringOrList `yaml:"language"`
+	Variant  stringOrList `yaml:"variant"`
+}
+
+// stringOrList accepts either a single string or a list of strings.
+type stringOrList []string
+
+func (l *stringOrList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = []string{value.Value}
+		return nil
+	}
+
+	var values []string
+	if err := value.Decode(&values); err != nil {
+		return err
+	}
+
+	*l = values
+	return nil
+}
+
+func (c *hookCondition) String() string {
+	var conditions []string
+	if len(c.Language) > 0 {
+		conditions = append(conditions, "language "+strings.Join(c.Language, "/"))
+	}
+
+	if len(c.Variant) > 0 {
+		conditions = append(conditions, "variant "+strings.Join(c.Variant, "/"))
+	}
+
+	return strings.Join(conditions, " and ")
+}
+
+func (c *hookCondition) validate() error {
+	for _, language := range c.Language {
+		if _, err := ParseLanguage(LanguageResolveAlias(language)); err != nil {
+			return fmt.Errorf("invalid 'only-for' language %q, accepted values are %s", language, strings.Join(configEnumValues(LanguageNames()), ", "))
+		}
+	}
+
+	for _, variant := range c.Variant {
+		if _, err := ParseVariant(VariantResolveAlias(variant)); err != nil {
+			return fmt.Errorf("invalid 'only-for' variant %q, accepted values are %s", variant, strings.Join(configEnumValues(VariantNames()), ", "))
+		}
+	}
+
+	return nil
+}
+
+func (c *hookCondition) matches(global *GlobalModel) bool {
+	languageMatches := len(c.Language) == 0 || slices.ContainsFunc(c.Language, func(language string) bool {
+		parsed, err := ParseLanguage(LanguageResolveAlias(language))
+		return err == nil && parsed == global.Language
+	})
+
+	variantMatches := len(c.Variant) == 0 || slices.ContainsFunc(c.Variant, func(variant string) bool {
+		parsed, err := ParseVariant(VariantResolveAlias(variant))
+		return err == nil && parsed == global.Variant
+	})
+
+	return languageMatches && variantMatches
+}
+
+// mustGetHooks returns the hooks of flag `name`. Hooks in the mapping form can only be defined
+// in the config file, flags and environment variables only accept the string form.
+func mustGetHooks(cmd *cobra.Command, name string) []*releaseHook {
+	flag := cmd.Flags().Lookup(name)
+	cli.Ensure(flag != nil, "Flag %q does not exist", name)
+
+	if keys := flag.Annotations[cli.ReboundFlagAnnotation]; len(keys) > 0 && !flag.Changed {
+		// Viper returns the raw list when the value comes from the config file
+		if raw, ok := viper.Get(keys[0]).([]any); ok {
+			hooks, err := parseHooks(raw)
+			cli.NoError(err, "Invalid %q config value", keys[0])
+
+			return hooks
+		}
+	}
+
+	return commandHooks(sflags.MustGetStringArray(cmd, name)...)
+}
+
+// commandHooks returns hooks running `commands` with the default options.
+func commandHooks(commands ...string) []*releaseHook {
+	hooks := make([]*releaseHook, len(commands))
+	for i, command := range commands {
+		hooks[i] = &releaseHook{Command: command}
+	}
+
+	return hooks
+}
+
+func parseHooks(raw []any) ([]*releaseHook, error) {
+	hooks := make([]*releaseHook, 0, len(raw))
+	for i, element := range raw {
+		hook, err := parseHook(element)
+		if err != nil {
+			return nil, fmt.Errorf("hook #%d: %w", i+1, err)
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, nil
+}
+
+func parseHook(raw any) (*releaseHook, error) {
+	if command, ok := raw.(string); ok {
+		return &releaseHook{Command: command}, nil
+	}
+
+	// Re-encoding the value decoded by Viper gives strict decoding and duration parsing for free
+	content, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+
+	if _, ok := raw.(map[string]any); !ok {
+		return nil, fmt.Errorf("expected a command or a mapping, got %s", strings.TrimSpace(string(content)))
+	}
+
+	hook := &releaseHook{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(hook); err != nil {
+		return nil, err
+	}
+
+	if hook.Command == "" {
+		return nil, fmt.Errorf("'command' is required")
+	}
+
+	for _, entry := range hook.Env {
+		if key, _, found := strings.Cut(entry, "="); !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid 'env' entry %q, expected 'KEY=value'", entry)
+		}
+	}
+
+	if hook.OnlyFor != nil {
+		if err := hook.OnlyFor.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return hook, nil
+}
+
+func executeHooks(hooks []*releaseHook, buildDir string, global *GlobalModel, release *ReleaseModel) {
+	executeHooksWithModel(hooks, map[string]any{
+		"global":   global,
+		"release":  release,
+		"buildDir": buildDir,
+	})
+}
+
+func executeHooksWithModel(hooks []*releaseHook, model map[string]any) {
+	environment := hookEnvironment(model)
+
+	for _, hook := range hooks {
+		executeHook(hook, model, environment)
+	}
+}
+
+func executeHook(hook *releaseHook, model map[string]any, environment []string) {
+	if global, ok := model["global"].(*GlobalModel); ok && hook.OnlyFor != nil && !hook.OnlyFor.matches(global) {
+		fmt.Printf("Skipping hook %q, it only applies to %s\n", hook.Command, hook.OnlyFor)
+		return
+	}
+
+	command := renderHookTemplate(hook.Command, model)
+	zlog.Debug("hook templated", zap.String("hook", command))
+
+	info := newCommandInfo(command)
+	info.dir = renderHookTemplate(hook.WorkingDir, model)
+	info.extraEnv = environment
+	for _, entry := range hook.Env {
+		info.extraEnv = append(info.extraEnv, renderHookTemplate(entry, model))
+	}
+
+	if _, err := runCommand(info, false, hook.Timeout); err != nil {
+		cli.Ensure(hook.ContinueOnError, "Hook %q failed: %s", info, err)
+
+		zlog.Warn(fmt.Sprintf("hook %q failed, continuing since 'continue-on-error' is set: %s", info, err))
+	}
+}
+
+func renderHookTemplate(content string, model map[string]any) string {
+	parsed, err := template.New("hook").Parse(content)
+	cli.NoError(err, "Parse hook template %q", content)
+
+	// Hook length + 10% as the initial buffer size
+	out := bytes.NewBuffer(make([]byte, 0, int(float64(len(content))*1.10)))
+	cli.NoError(parsed.Execute(out, model), "Unable to execute template")
+
+	return out.String()
+}
+
+// hookEnvironment returns the 'SFRELEASER_*' environment variables exported to every hook,
+// they hold the values of the hook's template `model` so that scripts can use them directly.
+func hookEnvironment(model map[string]any) (environment []string) {
+	export := func(name string, value string) {
+		environment = append(environment, "SFRELEASER_"+name+"="+value)
+	}
+
+	if global, ok := model["global"].(*GlobalModel); ok {
+		export("OWNER", global.Owner)
+		export("PROJECT", global.Project)
+		export("REPOSITORY", global.Owner+"/"+global.Project)
+		export("BINARY", global.Binary)
+		export("LANGUAGE", strings.ToLower(global.Language.String()))
+		export("VARIANT", strings.ToLower(global.Variant.String()))
+
+		if release, ok := model["release"].(*ReleaseModel); ok {
+			export("VERSION", release.Version)
+			if release.Version != "" {
+				export("TAG", global.Tag(release.Version))
+			}
+			export("PRERELEASE", strconv.FormatBool(release.Prerelease))
+		}
+	}
+
+	if buildDir, ok := model["buildDir"].(string); ok {
+		if absolute, err := filepath.Abs(buildDir); err == nil {
+			buildDir = absolute
+		}
+
+		export("BUILD_DIR", buildDir)
+	}
+
+	if releaseURL, ok := model["releaseURL"].(string); ok {
+		export("RELEASE_URL", releaseURL)
+	}
+
+	if published, ok := model["published"].(bool); ok {
+		export("PUBLISHED", strconv.FormatBool(published))
+	}
+
+	if assets, ok := model["assets"].([]*releaseSummaryAsset); ok {
+		names := make([]string, len(assets))
+		for i, asset := range assets {
+			names[i] = asset.Name
+		}
+
+		export("ASSETS", strings.Join(names, ","))
+	}
+
+	return environment
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_parseHooks(t *testing.T) {
+	parse := func(t *testing.T, content string) ([]*releaseHook, error) {
+		var raw []any
+		require.NoError(t, yaml.Unmarshal([]byte(content), &raw))
+
+		return parseHooks(raw)
+	}
+
+	hooks, err := parse(t, dedent(`
+		- make build
+		- command: ./package.sh {{ .release.Version }}
+		  working-dir: scripts
+		  env: ["PROFILE=release"]
+		  timeout: 5m
+		  continue-on-error: true
+		  only-for:
+		    language: go
+		    variant: [lib, substreams]
+	`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*releaseHook{
+		{Command: "make build"},
+		{
+			Command:         "./package.sh {{ .release.Version }}",
+			WorkingDir:      "scripts",
+			Env:             []string{"PROFILE=release"},
+			Timeout:         5 * time.Minute,
+			ContinueOnError: true,
+			OnlyFor:         &hookCondition{Language: stringOrList{"go"}, Variant: stringOrList{"lib", "substreams"}},
+		},
+	}, hooks)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing command", "- working-dir: scripts", "hook #1: 'command' is required"},
+		{"unknown field", "- make\n- command: make\n  timeot: 5m", "hook #2: yaml: unmarshal errors:\n  line 2: field timeot not found in type main.releaseHook"},
+		{"invalid timeout", "- command: make\n  timeout: later", `hook #1: yaml: unmarshal errors:`},
+		{"invalid env", "- command: make\n  env: [PROFILE]", `hook #1: invalid 'env' entry "PROFILE", expected 'KEY=value'`},
+		{"invalid language", "- command: make\n  only-for:\n    language: python", `hook #1: invalid 'only-for' language "python", accepted values are golang, rust`},
+		{"invalid form", "- [make, build]", "hook #1: expected a command or a mapping, got - make\n- build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(t, tt.content)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_hookCondition_matches(t *testing.T) {
+	global := &GlobalModel{Language: LanguageRust, Variant: VariantLibrary}
+
+	assert.True(t, (&hookCondition{}).matches(global))
+	assert.True(t, (&hookCondition{Language: stringOrList{"rust"}}).matches(global))
+	assert.True(t, (&hookCondition{Language: stringOrList{"go", "rust"}, Variant: stringOrList{"lib"}}).matches(global))
+	assert.False(t, (&hookCondition{Language: stringOrList{"go"}}).matches(global))
+	assert.False(t, (&hookCondition{Language: stringOrList{"rust"}, Variant: stringOrList{"application"}}).matches(global))
+}
+
+func Test_hookEnvironment(t *testing.T) {
+	global := &GlobalModel{Owner: "streamingfast", Project: "firehose", Binary: "fireeth", Language: LanguageGolang, Variant: VariantApplication, TagPrefix: "firehose-ethereum/"}
+
+	assert.Equal(t, []string{
+		"SFRELEASER_OWNER=streamingfast",
+		"SFRELEASER_PROJECT=firehose",
+		"SFRELEASER_REPOSITORY=streamingfast/firehose",
+		"SFRELEASER_BINARY=fireeth",
+		"SFRELEASER_LANGUAGE=golang",
+		"SFRELEASER_VARIANT=application",
+		"SFRELEASER_VERSION=v1.2.0",
+		"SFRELEASER_TAG=firehose-ethereum/v1.2.0",
+		"SFRELEASER_PRERELEASE=false",
+		"SFRELEASER_BUILD_DIR=/tmp/build",
+		"SFRELEASER_RELEASE_URL=https://github.com/streamingfast/firehose/releases/tag/firehose-ethereum/v1.2.0",
+		"SFRELEASER_PUBLISHED=true",
+		"SFRELEASER_ASSETS=fireeth_linux_x86_64.tar.gz,checksums.txt",
+	}, hookEnvironment(map[string]any{
+		"global":     global,
+		"release":    &ReleaseModel{Version: "v1.2.0"},
+		"buildDir":   "/tmp/build",
+		"releaseURL": "https://github.com/streamingfast/firehose/releases/tag/firehose-ethereum/v1.2.0",
+		"assets":     []*releaseSummaryAsset{{Name: "fireeth_linux_x86_64.tar.gz"}, {Name: "checksums.txt"}},
+		"published":  true,
+	}))
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -17,8 +18,28 @@ import (
 var InitCmd = Command(initCmd,
 	"init",
 	"Initialize the necessary configuration files",
+	Description(`
+		Creates the '.sfreleaser' config file (and optionally an empty 'CHANGELOG.md') for
+		your project.
+
+		The language, variant and binary are inferred from the project tree when not provided
+		through flags: a 'go.mod' with 'cmd/*/main.go' is a Golang application (the binary
+		being inferred when there is a single 'cmd/<name>' directory), a 'go.mod' alone is a
+		Golang library, a 'Cargo.toml' is a Rust library and a 'substreams.yaml' is a Rust
+		Substreams project. Detected values are proposed as defaults, use '--detect-only' to
+		only print what was inferred.
+
+		Use '--with-workflow' to also write a GitHub Actions workflow '.github/workflows/release.yml'
+		running 'sfreleaser release --non-interactive' when a version tag (with the '--tag-prefix'
+		if any) is pushed or when dispatched manually with the version to release. To run there,
+		'sfreleaser release' uses the pushed tag when it already points to the current commit,
+		skips the Git sync check when 'HEAD' is detached (the tag is checked out) and accepts the
+		'GITHUB_TOKEN' of the workflow.
+	`),
 	Flags(func(flags *pflag.FlagSet) {
 		flags.BoolP("overwrite", "f", false, "[Destructive] Overwrite configuration files that already exists")
+		flags.Bool("detect-only", false, "Only print the language, variant and binary inferred from the project tree, without writing anything")
+		flags.Bool("with-workflow", false, "Also write a GitHub Actions workflow '.github/workflows/release.yml' releasing on tag push or manual dispatch")
 	}),
 )
 
@@ -27,6 +48,8 @@ var InstallCmd = Command(installCmd,
 	"Initialize the necessary configuration files (deprecated: use 'init' instead)",
 	Flags(func(flags *pflag.FlagSet) {
 		flags.BoolP("overwrite", "f", false, "[Destructive] Overwrite configuration files that already exists")
+		flags.Bool("detect-only", false, "Only print the language, variant and binary inferred from the project tree, without writing anything")
+		flags.Bool("with-workflow", false, "Also write a GitHub Actions workflow '.github/workflows/release.yml' releasing on tag push or manual dispatch")
 	}),
 )
 
@@ -39,18 +62,37 @@ func installCmd(cmd *cobra.Command, args []string) error {
 func initCmd(cmd *cobra.Command, _ []string) error {
 	global := mustGetGlobal(cmd)
 	overwrite := sflags.MustGetBool(cmd, "overwrite")
+	detectOnly := sflags.MustGetBool(cmd, "detect-only")
+	withWorkflow := sflags.MustGetBool(cmd, "with-workflow")
 
 	zlog.Debug("starting 'sfreleaser init'",
 		zap.Inline(global),
 		zap.Bool("overwrite", overwrite),
+		zap.Bool("detect_only", detectOnly),
+		zap.Bool("with_workflow", withWorkflow),
 	)
 
+	cli.NoError(os.Chdir(global.WorkingDirectory), "Unable to change directory to %q", global.WorkingDirectory)
+
+	detection := detectProject(".")
+	if detectOnly {
+		fmt.Printf("Detected project in %q:\n", global.WorkingDirectory)
+		fmt.Println(indentAllLines(detection.String(), "  "))
+		return nil
+	}
+
 	if global.Language == LanguageUnset {
-		global.Language = promptLanguage()
+		global.Language = promptLanguage(detection.Language)
 	}
 
 	if global.Variant == VariantUnset {
-		global.Variant = promptVariant()
+		global.Variant = promptVariant(detection.Variant)
+	}
+
+	// The binary defaults to the project name, so it's replaced only if not explicitly configured
+	if detection.Binary != "" && sflags.MustGetString(cmd, "binary") == "" && global.Language == LanguageGolang && global.Variant == VariantApplication {
+		fmt.Printf("Using binary %q (%s)\n", detection.Binary, detection.BinaryReason)
+		global.Binary = detection.Binary
 	}
 
 	var noBinaries bool
@@ -67,8 +109,6 @@ func initCmd(cmd *cobra.Command, _ []string) error {
 		noBinaries = true
 	}
 
-	cli.NoError(os.Chdir(global.WorkingDirectory), "Unable to change directory to %q", global.WorkingDirectory)
-
 	model := getInstallTemplateModel(global, noBinaries)
 
 	var sfreleaserYamlTmpl []byte
@@ -94,6 +134,10 @@ func initCmd(cmd *cobra.Command, _ []string) error {
 
 	renderInstallTemplate(".sfreleaser", overwrite, sfreleaserYamlTmpl, model)
 
+	if withWorkflow {
+		renderInstallTemplate(filepath.Join(".github", "workflows", "release.yml"), overwrite, githubReleaseWorkflowTmpl, model)
+	}
+
 	if !cli.FileExists("CHANGELOG.md") {
 		if yes, _ := cli.PromptConfirm("Do you want to generate an empty CHANGELOG.md file?"); yes {
 			renderInstallTemplate("CHANGELOG.md", false, changelogTmpl, model)
@@ -120,7 +164,7 @@ type RustInstallModel struct {
 
 func addRustModel(model map[string]any) map[string]any {
 	model["rust"] = &RustInstallModel{
-		Crates: findAllRustCrates(),
+		Crates: findAllRustCrates("."),
 	}
 
 	return model
@@ -129,6 +173,8 @@ func addRustModel(model map[string]any) map[string]any {
 var templateFuncs = template.FuncMap{
 	"lower": transformStringFunc(strings.ToLower),
 	"upper": transformStringFunc(strings.ToUpper),
+	// ghExpr renders a GitHub Actions expression, '${{ <expr> }}' would otherwise be parsed as a template action
+	"ghExpr": func(expr string) string { return "${{ " + expr + " }}" },
 }
 
 func transformStringFunc(transformer func(in string) string) func(in any) string {
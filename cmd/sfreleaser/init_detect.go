@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/streamingfast/cli"
+	"go.uber.org/zap"
+)
+
+// projectDetection holds what could be inferred from the project tree, values are left
+// unset when nothing conclusive was found. Each reason explains how its value was inferred.
+type projectDetection struct {
+	Language       Language
+	LanguageReason string
+	Variant        Variant
+	VariantReason  string
+	Binary         string
+	BinaryReason   string
+}
+
+// detectProject infers the language, variant and binary of the project rooted at `root`:
+//   - 'substreams.yaml' means a Rust Substreams project
+//   - 'Cargo.toml' means a Rust library (Rust applications are released without binaries)
+//   - 'go.mod' with 'cmd/*/main.go' means a Golang application, the binary being inferred
+//     when there is a single 'cmd/<name>' directory, 'go.mod' alone means a Golang library
+func detectProject(root string) *projectDetection {
+	detection := &projectDetection{}
+
+	switch {
+	case cli.FileExists(filepath.Join(root, "substreams.yaml")):
+		detection.Language, detection.LanguageReason = LanguageRust, "'substreams.yaml' found"
+		detection.Variant, detection.VariantReason = VariantSubstreams, "'substreams.yaml' found"
+
+	case cli.FileExists(filepath.Join(root, "Cargo.toml")):
+		detection.Language, detection.LanguageReason = LanguageRust, "'Cargo.toml' found"
+
+		if crates := findAllRustCrates(root); len(crates) > 0 {
+			slices.Sort(crates)
+			detection.Variant, detection.VariantReason = VariantLibrary, fmt.Sprintf("%d crate(s) found: %s", len(crates), strings.Join(crates, ", "))
+		}
+
+	case cli.FileExists(filepath.Join(root, "go.mod")):
+		detection.Language, detection.LanguageReason = LanguageGolang, "'go.mod' found"
+
+		mains, err := filepath.Glob(filepath.Join(root, "cmd", "*", "main.go"))
+		cli.NoError(err, "Unable to list 'cmd/*/main.go' files")
+
+		if len(mains) == 0 {
+			detection.Variant, detection.VariantReason = VariantLibrary, "no 'cmd/*/main.go' found"
+			break
+		}
+
+		var binaries []string
+		for _, main := range mains {
+			binaries = append(binaries, filepath.Base(filepath.Dir(main)))
+		}
+
+		if len(binaries) == 1 {
+			detection.Variant, detection.VariantReason = VariantApplication, fmt.Sprintf("'cmd/%s/main.go' found", binaries[0])
+			detection.Binary, detection.BinaryReason = binaries[0], fmt.Sprintf("single 'cmd/%s' directory", binaries[0])
+		} else {
+			detection.Variant, detection.VariantReason = VariantApplication, fmt.Sprintf("'cmd/{%s}/main.go' found", strings.Join(binaries, ","))
+		}
+	}
+
+	zlog.Debug("detected project", zap.String("root", root), zap.Reflect("detection", detection))
+	return detection
+}
+
+func (d *projectDetection) String() string {
+	value := func(value fmt.Stringer, unset bool, reason string) string {
+		if unset {
+			return "<Not detected>"
+		}
+
+		return fmt.Sprintf("%s (%s)", value, reason)
+	}
+
+	binary := "<Not detected>"
+	if d.Binary != "" {
+		binary = fmt.Sprintf("%s (%s)", d.Binary, d.BinaryReason)
+	}
+
+	return strings.Join([]string{
+		"Language: " + value(d.Language, d.Language == LanguageUnset, d.LanguageReason),
+		"Variant:  " + value(d.Variant, d.Variant == VariantUnset, d.VariantReason),
+		"Binary:   " + binary,
+	}, "\n")
+}
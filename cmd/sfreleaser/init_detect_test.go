@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_detectProject(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  *projectDetection
+	}{
+		{
+			"golang application",
+			map[string]string{"go.mod": "module example.com/app", "cmd/app/main.go": "package main"},
+			&projectDetection{
+				Language: LanguageGolang, LanguageReason: "'go.mod' found",
+				Variant: VariantApplication, VariantReason: "'cmd/app/main.go' found",
+				Binary: "app", BinaryReason: "single 'cmd/app' directory",
+			},
+		},
+		{
+			"golang application multiple binaries",
+			map[string]string{"go.mod": "module example.com/app", "cmd/one/main.go": "package main", "cmd/two/main.go": "package main"},
+			&projectDetection{
+				Language: LanguageGolang, LanguageReason: "'go.mod' found",
+				Variant: VariantApplication, VariantReason: "'cmd/{one,two}/main.go' found",
+			},
+		},
+		{
+			"golang library",
+			map[string]string{"go.mod": "module example.com/lib", "lib.go": "package lib"},
+			&projectDetection{
+				Language: LanguageGolang, LanguageReason: "'go.mod' found",
+				Variant: VariantLibrary, VariantReason: "no 'cmd/*/main.go' found",
+			},
+		},
+		{
+			"rust workspace",
+			map[string]string{
+				"Cargo.toml":        "[workspace]\nmembers = [\"core\", \"macros\"]\n",
+				"core/Cargo.toml":   "[package]\nname = \"my-core\"\n",
+				"macros/Cargo.toml": "[package]\nname = \"my-macros\"\n",
+			},
+			&projectDetection{
+				Language: LanguageRust, LanguageReason: "'Cargo.toml' found",
+				Variant: VariantLibrary, VariantReason: "2 crate(s) found: my-core, my-macros",
+			},
+		},
+		{
+			"substreams",
+			map[string]string{"Cargo.toml": "[package]\nname = \"substreams-example\"\n", "substreams.yaml": "specVersion: v0.1.0\n"},
+			&projectDetection{
+				Language: LanguageRust, LanguageReason: "'substreams.yaml' found",
+				Variant: VariantSubstreams, VariantReason: "'substreams.yaml' found",
+			},
+		},
+		{
+			"unknown",
+			map[string]string{"README.md": "# Hello"},
+			&projectDetection{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for file, content := range tt.files {
+				path := filepath.Join(root, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+
+			assert.Equal(t, tt.want, detectProject(root))
+		})
+	}
+}
@@ -43,11 +43,21 @@ func main() {
 		DoctorCmd,
 		BuildCmd,
 		ReleaseCmd,
+		ReleaseAllCmd,
 		InitCmd,
 		InstallCmd,
 
 		Group("changelog", "Commands to manipulate changelog files",
 			ChangelogExtractSectionCmd,
+			ChangelogLintCmd,
+			ChangelogPromoteCmd,
+		),
+
+		Group("config", "Commands to inspect and validate the '.sfreleaser' config file",
+			ConfigValidateCmd,
+			ConfigSchemaCmd,
+			ConfigShowCmd,
+			ConfigMigrateCmd,
 		),
 
 		Description(`
@@ -73,6 +83,8 @@ func main() {
 			flags.String("root", "", "If defined, change the working directory of the process before proceeding with the release")
 			flags.String("sfreleaser-min-version", "", "If sets, will check that the version of sfreleaser is at least this version before attempting the build")
 			flags.String("git-remote", "origin", "The git remote to use for pushing the release and commits")
+			flags.String("tag-prefix", "", "Prefix of the Git tags of the project (e.g. 'firehose-ethereum/' for tag 'firehose-ethereum/v1.2.3'), used to release multiple projects from the same repository")
+			flags.String("profile", "", "Name of the profile (defined under 'profiles' in the '.sfreleaser' file) whose 'global', 'release' and 'build' values overlay the config file ones")
 		}),
 	)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -36,6 +37,11 @@ type GlobalModel struct {
 
 	GitRemote string
 
+	// TagPrefix is prepended to the version to form the Git tag of a release (e.g.
+	// 'firehose-ethereum/' for tag 'firehose-ethereum/v1.2.3'), it enables releasing
+	// multiple projects living in the same repository.
+	TagPrefix string
+
 	// WorkingDirectory is the absolute path to directory all command should use
 	// when execution and is computed based on other configuration values found
 	// in this model.
@@ -55,6 +61,7 @@ func (g *GlobalModel) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	encoder.AddString("config_root", g.ConfigRoot)
 	encoder.AddString("working_directory", g.WorkingDirectory)
 	encoder.AddString("git_remote", g.GitRemote)
+	encoder.AddString("tag_prefix", g.TagPrefix)
 
 	return nil
 }
@@ -119,6 +126,7 @@ func mustGetGlobal(cmd *cobra.Command) *GlobalModel {
 		Variant:   mustGetVariant(cmd),
 		Root:      sflags.MustGetString(cmd, "root"),
 		GitRemote: sflags.MustGetString(cmd, "git-remote"),
+		TagPrefix: sflags.MustGetString(cmd, "tag-prefix"),
 	}
 
 	global.WorkingDirectory = cli.WorkingDirectory()
@@ -128,10 +136,20 @@ func mustGetGlobal(cmd *cobra.Command) *GlobalModel {
 
 	if global.Project == "" {
 		global.Project = filepath.Base(global.WorkingDirectory)
+
+		// In a monorepo, the project is the repository while the directory is the released component
+		if global.TagPrefix != "" {
+			if topLevel, err := gitTopLevelDirectory(global.WorkingDirectory); err == nil {
+				global.Project = filepath.Base(topLevel)
+			}
+		}
 	}
 
 	if global.Binary == "" {
 		global.Binary = global.Project
+		if global.TagPrefix != "" {
+			global.Binary = filepath.Base(global.WorkingDirectory)
+		}
 	}
 
 	global.ConfigRoot = findSfreleaserDir(global.WorkingDirectory)
@@ -139,6 +157,16 @@ func mustGetGlobal(cmd *cobra.Command) *GlobalModel {
 	return global
 }
 
+// Tag returns the Git tag of `version`, which is the version prefixed by [TagPrefix].
+func (g *GlobalModel) Tag(version string) string {
+	return g.TagPrefix + version
+}
+
+// TrimTagPrefix returns the version of `in` which can either be a version or a tag.
+func (g *GlobalModel) TrimTagPrefix(in string) string {
+	return strings.TrimPrefix(in, g.TagPrefix)
+}
+
 func (g *GlobalModel) ResolveFile(in string) string {
 	if filepath.IsAbs(in) {
 		return in
@@ -219,6 +247,11 @@ type ReleaseModel struct {
 	// Note: This flag cannot be used with library variant as libraries already skip binary builds.
 	NoBinaries bool
 
+	// Prerelease is true when the version has a pre-release suffix (like "-rc.1" in "v1.0.0-rc.1"),
+	// the GitHub release is then marked as pre-release and never as the latest release. It's
+	// populated once the version is known, see [ReleaseModel.setVersion].
+	Prerelease bool
+
 	Brew *BrewReleaseModel
 
 	// Rust is populated only if config if of type Rust
@@ -255,6 +288,7 @@ func (m *ReleaseModel) populate(cmd *cobra.Command, global *GlobalModel) {
 
 	m.Brew = &BrewReleaseModel{
 		Disabled:     sflags.MustGetBool(cmd, "brew-disabled"),
+		Prerelease:   sflags.MustGetBool(cmd, "brew-prerelease"),
 		TapRepoOwner: tapRepoOwner,
 		TapRepoName:  tapRepoName,
 	}
@@ -279,6 +313,18 @@ func (m *ReleaseModel) populate(cmd *cobra.Command, global *GlobalModel) {
 	}
 }
 
+// setVersion sets the version being released and adjusts the model when it's a pre-release
+// version, in which case the Brew tap is not updated unless explicitly requested.
+func (m *ReleaseModel) setVersion(version string) {
+	m.Version = version
+	m.Prerelease = isPrereleaseVersion(version)
+
+	if m.Prerelease && m.Brew != nil && !m.Brew.Disabled && !m.Brew.Prerelease {
+		fmt.Printf("Version %q is a pre-release, Brew tap will not be updated (use 'brew-prerelease: true' to update it anyway)\n", version)
+		m.Brew.Disabled = true
+	}
+}
+
 func findFile(root string, matcher func(in string) bool) *string {
 	entries, err := os.ReadDir(root)
 	if err != nil {
@@ -329,7 +375,9 @@ type GitHubReleaseModel struct {
 }
 
 type BrewReleaseModel struct {
-	Disabled     bool
+	Disabled bool
+	// Prerelease when true updates the Brew tap even for pre-release versions
+	Prerelease   bool
 	TapRepoOwner string
 	TapRepoName  string
 }
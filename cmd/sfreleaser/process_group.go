@@ -0,0 +1,24 @@
+//go:build !windows
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup makes `cmd` the leader of a new process group, so that it can be killed with
+// the processes it starts through [killProcessGroup].
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killProcessGroup kills the process group led by `cmd`, which must be the leader of its own
+// process group (or session).
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
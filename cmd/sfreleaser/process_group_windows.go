@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, the processes started by `cmd` are not killed with it.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/bobg/go-generics/v2/slices"
 	versioning "github.com/hashicorp/go-version"
@@ -10,67 +13,273 @@ import (
 	"go.uber.org/zap"
 )
 
-func promptLanguage() Language {
-	return cli.PromptSelect("Project language", slices.Filter(LanguageNames(), isSupportedLanguage), ParseLanguage)
+// nonInteractive is true when no prompt can be shown (like on a CI runner), each prompt is then
+// answered by its flag, see [confirm].
+var nonInteractive bool
+
+// isCIEnvironment returns true when the `CI` environment variable is set to a true value, which
+// is the case on most CI providers (GitHub Actions, GitLab CI, CircleCI, etc.).
+func isCIEnvironment() bool {
+	ci, err := strconv.ParseBool(os.Getenv("CI"))
+	return err == nil && ci
+}
+
+// confirm asks `label` to the user unless the answer is already known. When `assumeYes` is true
+// (e.g. flag `--<flag>` was set), the answer is yes. In non-interactive mode, the answer is then no.
+func confirm(label string, flag string, assumeYes bool) bool {
+	if assumeYes {
+		fmt.Printf("%s Yes (from --%s)\n", label, flag)
+		return true
+	}
+
+	if nonInteractive {
+		fmt.Printf("%s No (non-interactive, use --%s to answer yes)\n", label, flag)
+		return false
+	}
+
+	yes, _ := cli.PromptConfirm(label)
+	return yes
+}
+
+// promptLanguage asks for the project language, the `detected` one (if set) is listed first
+// which makes it the one selected by default.
+func promptLanguage(detected Language) Language {
+	return cli.PromptSelect("Project language", detectedFirst(slices.Filter(LanguageNames(), isSupportedLanguage), detected.String(), detected == LanguageUnset), parseDetected(ParseLanguage))
+}
+
+// promptVariant asks for the project variant, the `detected` one (if set) is listed first
+// which makes it the one selected by default.
+func promptVariant(detected Variant) Variant {
+	return cli.PromptSelect("Project variant", detectedFirst(slices.Filter(VariantNames(), isSupportedVariant), detected.String(), detected == VariantUnset), parseDetected(ParseVariant))
+}
+
+const detectedSuffix = " (detected)"
+
+func detectedFirst(names []string, detected string, unset bool) []string {
+	if unset {
+		return names
+	}
+
+	out := []string{detected + detectedSuffix}
+	for _, name := range names {
+		if name != detected {
+			out = append(out, name)
+		}
+	}
+
+	return out
 }
 
-func promptVariant() Variant {
-	return cli.PromptSelect("Project variant", slices.Filter(VariantNames(), isSupportedVariant), ParseVariant)
+func parseDetected[T any](parse func(string) (T, error)) func(string) (T, error) {
+	return func(item string) (T, error) {
+		return parse(strings.TrimSuffix(item, detectedSuffix))
+	}
 }
 
-func promptVersion(changelogPath string, gitRemote string) string {
-	latestTag := latestTag(gitRemote)
+// promptVersion asks for the version to release. When `releaseCandidate` or `nextReleaseCandidate`
+// is true, the version is instead resolved without prompting, see [releaseCandidateVersion]. In
+// non-interactive mode, the changelog version is used and it's an error if there is none.
+//
+// Only the tags starting with `tagPrefix` are considered, the returned version never contains it.
+func promptVersion(changelogPath string, gitRemote string, tagPrefix string, releaseCandidate bool, nextReleaseCandidate bool) string {
+	latestTag := latestTag(gitRemote, tagPrefix)
+	latestTagVersion := strings.TrimPrefix(latestTag, tagPrefix)
 	defaultVersion := readVersionFromChangelog(changelogPath)
 
+	if releaseCandidate || nextReleaseCandidate {
+		version := releaseCandidateVersion(changelogPath, gitRemote, tagPrefix, latestTag, defaultVersion, nextReleaseCandidate)
+		fmt.Printf("Releasing release candidate %q (current latest tag is %s)\n", version, orNeverReleased(latestTag))
+		return version
+	}
+
 	zlog.Debug("asking for version via terminal", zap.String("default", defaultVersion), zap.String("changelog_path", changelogPath))
-	if defaultVersion == latestTag && latestTag != "" {
+	if defaultVersion == latestTagVersion && latestTag != "" {
 		cli.Quit(cli.Dedent(`
 			Latest tag %q is the same as latest version extracted from your changelog, you can't
 			release the same version twice.
 		`), latestTag)
 	}
 
+	if nonInteractive {
+		if defaultVersion == "" {
+			cli.Quit("%s", cli.Dedent(`
+				No version to release in non-interactive mode, provide it as an argument (like 'sfreleaser
+				release v1.2.3'), use --rc/--next-rc or add a '## v1.2.3' section to your changelog.
+			`))
+		}
+
+		fmt.Printf("Releasing version %q found in changelog (current latest tag is %s)\n", defaultVersion, orNeverReleased(latestTag))
+		return defaultVersion
+	}
+
 	if defaultVersion == "" && latestTag != "" {
-		latestVersion, err := versioning.NewVersion(latestTag)
+		latestVersion, err := versioning.NewVersion(latestTagVersion)
 		if err == nil {
-			latestSegments := latestVersion.Segments()
+			version, recommendedVersion := promptVersionBump(changelogPath, gitRemote, latestTag, latestVersion)
+			if version != "" {
+				return version
+			}
 
-			// Version is always valid
-			nextVersion, _ := versioning.NewVersion(fmt.Sprintf("%d.%d.%d", latestSegments[0], latestSegments[1], latestSegments[2]+1))
-			defaultVersion = "v" + nextVersion.String()
+			defaultVersion = recommendedVersion
 		}
 	}
 
 	opts := []cli.PromptOption{
-		validateVersionPrompt(),
+		validateVersionPrompt(tagPrefix),
 	}
 
 	if defaultVersion != "" {
 		opts = append(opts, cli.WithPromptDefaultValue(defaultVersion))
 	}
 
-	currentLatestTag := latestTag
-	if currentLatestTag == "" {
-		currentLatestTag = "'Never released yet'"
-	}
-
-	return cli.Prompt(
-		fmt.Sprintf("What version do you want to release (current latest tag is %s)", currentLatestTag),
+	version := cli.Prompt(
+		fmt.Sprintf("What version do you want to release (current latest tag is %s)", orNeverReleased(latestTag)),
 		cli.PromptTypeString,
 		opts...,
 	)
+
+	return strings.TrimPrefix(version, tagPrefix)
+}
+
+const otherVersionChoice = "Other version (enter it manually)"
+
+// promptVersionBump offers the next major, minor, patch and pre-release versions of `latestVersion`
+// with the recommended bump (see [recommendVersionBump]) selected by default. The returned `version`
+// is empty if the user prefers to enter the version manually.
+func promptVersionBump(changelogPath string, gitRemote string, latestTag string, latestVersion *versioning.Version) (version string, recommendedVersion string) {
+	recommendation := recommendVersionBumpSinceTag(changelogPath, gitRemote, latestTag)
+	if latestVersion.Prerelease() != "" {
+		recommendation = &bumpRecommendation{versionBumpFinal, fmt.Sprintf("latest tag %s is a pre-release of it", latestTag)}
+	}
+
+	choices := nextVersionChoices(latestVersion, recommendation.Bump)
+
+	// There is no way to pre-select an item, so the recommended one is listed first which
+	// makes it the one selected by default.
+	var items []string
+	versionByItem := map[string]string{}
+	for _, choice := range choices {
+		if choice.Bump != recommendation.Bump {
+			continue
+		}
+
+		item := fmt.Sprintf("%s (%s, recommended: %s)", choice.Version, choice.Bump, recommendation.Reason)
+		items = append(items, item)
+		versionByItem[item] = choice.Version
+		recommendedVersion = choice.Version
+	}
+
+	for _, choice := range choices {
+		if choice.Bump == recommendation.Bump {
+			continue
+		}
+
+		item := fmt.Sprintf("%s (%s)", choice.Version, choice.Bump)
+		items = append(items, item)
+		versionByItem[item] = choice.Version
+	}
+
+	items = append(items, otherVersionChoice)
+
+	zlog.Debug("asking for version bump via terminal", zap.String("recommended", recommendedVersion), zap.String("reason", recommendation.Reason))
+
+	version = cli.PromptSelect(
+		fmt.Sprintf("What version do you want to release (current latest tag is %s)", latestTag),
+		items,
+		func(item string) (string, error) { return versionByItem[item], nil },
+	)
+
+	return version, recommendedVersion
+}
+
+// recommendVersionBumpSinceTag recommends a version bump from the 'Unreleased' section of the
+// changelog and the commits since `latestTag`, see [recommendVersionBump].
+func recommendVersionBumpSinceTag(changelogPath string, gitRemote string, latestTag string) *bumpRecommendation {
+	var unreleased *ChangelogRelease
+	if cli.FileExists(changelogPath) {
+		changelog, err := parseChangelogFile(changelogPath)
+		cli.NoError(err, "Unable to parse changelog %q", changelogPath)
+
+		if len(changelog.Releases) > 0 && changelog.Releases[0].Unreleased {
+			unreleased = changelog.Releases[0]
+		}
+	}
+
+	commits, err := readCommitsSinceTag(gitRemote, latestTag)
+	if err != nil {
+		zlog.Debug("unable to read commits since latest tag, ignoring them for version bump recommendation", zap.Error(err))
+	}
+
+	return recommendVersionBump(unreleased, commits)
+}
+
+// releaseCandidateVersion resolves the release candidate version to release. With `next`, the
+// latest tag must be a pre-release and its counter is incremented. Otherwise, it's the next
+// release candidate (the first one unless some were already tagged) of the changelog version if
+// defined, of the recommended next version otherwise.
+func releaseCandidateVersion(changelogPath string, gitRemote string, tagPrefix string, latestTag string, changelogVersion string, next bool) string {
+	var latestVersion *versioning.Version
+	if latestTag != "" {
+		var err error
+		latestVersion, err = versioning.NewVersion(strings.TrimPrefix(latestTag, tagPrefix))
+		cli.NoError(err, "Latest tag %q is not a valid version", latestTag)
+	}
+
+	if next {
+		cli.Ensure(latestVersion != nil && latestVersion.Prerelease() != "", "Latest tag %q is not a pre-release, use --rc to release the first release candidate of the next version", latestTag)
+
+		return findVersionChoice(nextVersionChoices(latestVersion, versionBumpFinal), versionBumpPrerelease).Version
+	}
+
+	if changelogVersion != "" {
+		changelogSemver, err := versioning.NewVersion(changelogVersion)
+		cli.NoError(err, "Changelog version %q is not a valid version", changelogVersion)
+
+		segments := changelogSemver.Segments()
+		return nextRemoteReleaseCandidate(gitRemote, tagPrefix, fmt.Sprintf("v%d.%d.%d", segments[0], segments[1], segments[2]))
+	}
+
+	cli.Ensure(latestVersion != nil, "No tag found to compute the next version from, provide the version explicitly, like 'sfreleaser release v0.1.0-rc.1'")
+	cli.Ensure(latestVersion.Prerelease() == "", "Latest tag %q is already a pre-release, use --next-rc to release its next release candidate", latestTag)
+
+	recommendation := recommendVersionBumpSinceTag(changelogPath, gitRemote, latestTag)
+	return nextRemoteReleaseCandidate(gitRemote, tagPrefix, findVersionChoice(nextVersionChoices(latestVersion, recommendation.Bump), recommendation.Bump).Version)
+}
+
+// nextRemoteReleaseCandidate returns the release candidate of `version` following the ones
+// already tagged on `gitRemote`, see [nextReleaseCandidate].
+func nextRemoteReleaseCandidate(gitRemote string, tagPrefix string, version string) string {
+	tags := remoteTags(gitRemote, tagPrefix+"*"+strings.TrimPrefix(version, "v")+"-rc.*")
+
+	return nextReleaseCandidate(version, tagPrefix, tags)
+}
+
+func orNeverReleased(latestTag string) string {
+	if latestTag == "" {
+		return "'Never released yet'"
+	}
+
+	return latestTag
 }
 
 var cliVersionRegexp = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+`)
 
-func validVersion(in string) error {
-	if !cliVersionRegexp.MatchString(in) {
+// validVersion checks that `in` is a version, optionally prefixed by `tagPrefix` so that the
+// tag of the version is accepted too.
+func validVersion(in string, tagPrefix string) error {
+	if !cliVersionRegexp.MatchString(strings.TrimPrefix(in, tagPrefix)) {
+		if tagPrefix != "" {
+			return fmt.Errorf(`version %q must of the form "^[%s]v{major}.{minor}.{patch}" (end of input is free-form)`, in, tagPrefix)
+		}
+
 		return fmt.Errorf(`version %q must of the form "^v{major}.{minor}.{patch}" (end of input is free-form)`, in)
 	}
 
 	return nil
 }
 
-func validateVersionPrompt() cli.PromptOption {
-	return cli.WithPromptValidate("invalid version", validVersion)
+func validateVersionPrompt(tagPrefix string) cli.PromptOption {
+	return cli.WithPromptValidate("invalid version", func(in string) error {
+		return validVersion(in, tagPrefix)
+	})
 }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		tagPrefix string
+		wantErr   string
+	}{
+		{"version", "v1.2.3", "", ""},
+		{"pre-release", "v1.2.3-rc.1", "", ""},
+		{"missing v", "1.2.3", "", `version "1.2.3" must of the form "^v{major}.{minor}.{patch}" (end of input is free-form)`},
+		{"tag without prefix", "component/v1.2.3", "", `version "component/v1.2.3" must of the form "^v{major}.{minor}.{patch}" (end of input is free-form)`},
+		{"version with prefix", "v1.2.3", "component/", ""},
+		{"tag with prefix", "component/v1.2.3", "component/", ""},
+		{"tag of other prefix", "other/v1.2.3", "component/", `version "other/v1.2.3" must of the form "^[component/]v{major}.{minor}.{patch}" (end of input is free-form)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validVersion(tt.in, tt.tagPrefix)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -52,26 +52,192 @@ var ReleaseCmd = Command(release,
 		ends with a '.spkg' extension, it's appended as is. Otherwise, it's assume to be a Substreams
 		project in which case we build the '.spkg' for you.
 
-		## Pre-build hooks template
+		## Hooks options
 
-		When using the 'pre-build-hooks' config value, you can use the following template variables:
+		Each hook is either a command or a mapping holding the command and its options, the
+		mapping form is only accepted in the config file:
+
+			release:
+				pre-build-hooks:
+				- make generate
+				- command: ./package.sh {{ .release.Version }}
+				  # Relative to the project directory, the current directory when unset
+				  working-dir: scripts
+				  # Extra environment variables, values can be templated
+				  env: ["PROFILE=release"]
+				  # The hook is killed (and fails) if it's still running after this duration
+				  timeout: 5m
+				  # A failure is printed as a warning instead of stopping the release
+				  continue-on-error: true
+				  # Skipped for other languages/variants, each accepts a value or a list
+				  only-for:
+				    language: rust
+				    variant: [library, substreams]
+
+		Every hook receives the release information as environment variables, so scripts don't
+		need template syntax: 'SFRELEASER_OWNER', 'SFRELEASER_PROJECT', 'SFRELEASER_REPOSITORY',
+		'SFRELEASER_BINARY', 'SFRELEASER_LANGUAGE', 'SFRELEASER_VARIANT', 'SFRELEASER_VERSION',
+		'SFRELEASER_TAG', 'SFRELEASER_PRERELEASE' and 'SFRELEASER_BUILD_DIR'. The post-release and
+		post-publish hooks also receive 'SFRELEASER_RELEASE_URL', 'SFRELEASER_PUBLISHED' and
+		'SFRELEASER_ASSETS' (the comma separated asset names).
+
+		## Hooks template
+
+		When using the 'pre-build-hooks' config value, the command, working directory and environment
+		values can use the following template variables:
 		- {{ .global }}: The global model containing project information (see https://github.com/streamingfast/sfreleaser/blob/master/cmd/sfreleaser/models.go#L13)
 		- {{ .release }}: The release model containing release specific information (see https://github.com/streamingfast/sfreleaser/blob/master/cmd/sfreleaser/models.go#L115)
-		- {{ .build_dir }}: The final build directory used for the build
+		- {{ .buildDir }}: The final build directory used for the build
+
+		The 'post-release-hooks' run once the GitHub release is created (as a draft unless published
+		right away) and its assets uploaded, the 'post-publish-hooks' once the release is published
+		(they don't run if it stays a draft). Both can use the same template variables plus:
+		- {{ .releaseURL }}: The URL of the GitHub release
+		- {{ .assets }}: The assets of the GitHub release, each with a 'Name', 'Size' and 'URL'
+		- {{ .published }}: Whether the GitHub release is published (false while it's a draft)
+
+		For example, to bump the dependency of a downstream project once published:
+
+			release:
+				post-publish-hooks:
+				- ./scripts/bump-downstream.sh {{ .release.Version }} {{ .releaseURL }}
+
+		## Dry-run
+
+		Use '--dry-run' to resolve the configuration, render 'build/goreleaser.yaml', template
+		the pre-build hooks as well as the extra assets paths and print the ordered list of
+		commands that would be executed. Nothing is pushed, tagged or uploaded, read-only
+		commands (like finding the latest tag or checking Git sync state) are still executed.
+
+		## Resume
+
+		Each step of the release is recorded in 'build/.release_state.json' as it completes (git
+		sync, release notes, hooks, tag, goreleaser release, each extra asset upload, post-release
+		hooks, crates or Substreams package publishing, the final publish and post-publish hooks).
+		If the release fails midway, fix the problem and run 'sfreleaser release --resume' to skip
+		already completed steps and retry from the failed one using the same version and release
+		notes. The file is removed once the release is published, there is nothing left to resume.
+
+		## On failure
+
+		When the release fails or is interrupted after goreleaser started creating the draft
+		release, the draft is handled according to 'on-failure' (under 'release' section):
+		- keep: The draft release is left as is so that '--resume' continues with it (default)
+		- delete-draft: The draft release and its uploaded assets are deleted, '--resume' creates it again
+		- prompt: Ask whether to delete the draft release, it's kept in non-interactive mode
+
+		The 'on-failure-hooks' then run, with the same template variables as the 'pre-build-hooks'
+		plus '{{ .releaseURL }}', the URL of the release left on GitHub (empty if there is none).
+		A failing on-failure hook is reported and the next ones still run:
 
+			release:
+				on-failure: delete-draft
+				on-failure-hooks:
+				- ./scripts/notify-failure.sh {{ .release.Version }}
+
+		## Release Notes
+
+		By default, the release notes are the changelog section of the released version ('## v1.3.0'),
+		the section of its base version for a pre-release ('## v1.3.0' for 'v1.3.0-rc.1') and the
+		first section (usually '## Unreleased') when there is none. Use 'notes-source: commits'
+		(under 'release' section) to instead generate them from the conventional commits
+		(https://www.conventionalcommits.org) since the latest tag, grouped as breaking changes,
+		features, fixes, performance and other changes with links to their pull request. Use
+		'notes-source: hybrid' to keep the changelog notes and append those commits in a "Commits"
+		section. The computed notes are written to 'build/.release_notes.md'.
+
+		## Pre-release
+
+		Versions with a pre-release suffix (like 'v1.0.0-rc.1' or 'v1.0.0-beta.2') are released
+		as GitHub pre-releases and are never marked as the latest release. The Brew tap is not
+		updated for them unless 'brew-prerelease: true' is set. Use '--rc' to release the first
+		release candidate not tagged yet of the next version or '--next-rc' to increment the
+		release candidate of the latest tag.
+
+		## Changelog Promotion
+
+		When the changelog starts with a non-empty '## Unreleased' section, you are offered to
+		promote it to the released version (see 'sfreleaser changelog promote'), the change is then
+		committed and pushed before the release tag is created.
+
+		## Non-interactive
+
+		Use '--non-interactive' (or '--yes') to never prompt, which is automatically the case when
+		the 'CI' environment variable is 'true'. Each question is then answered by its flag
+		('--delete-existing-draft', '--git-pull', '--promote-changelog' and '--publish-now'),
+		an unset flag meaning no. The version must be provided as an argument, through
+		'--rc'/'--next-rc' or be the first version of the changelog, otherwise the release fails
+		right away. Once completed, a JSON summary of the release (URL, assets and published
+		state) is printed, it's also always written to 'build/.release_summary.json'.
+
+		## JSON Output
+
+		Use '--output json' to emit the progress of the release as newline-delimited JSON events
+		on standard output, every other output (including the output of the tools executed) then
+		goes to standard error. Each event has a 'time' and a 'type' field, the types are 'started',
+		'step_started', 'step_finished' (with 'duration_ms'), 'step_skipped', 'command' (with
+		'duration_ms' and 'success'), 'asset_uploaded', 'release_url' (with 'published'),
+		'completed' and 'error' (with the failing 'step', if any). Combine it with
+		'--non-interactive' as prompts cannot be answered in this mode.
+
+		## Profiles
+
+		A project released in different ways can define named profiles in its '.sfreleaser' file,
+		each one overlaying the 'global', 'release' and 'build' values when selected with
+		'--profile <name>' (or 'global.profile'). Flags and environment variables still win over
+		profile values, use 'sfreleaser config show --profile <name>' to see the effective values.
+
+		  release:
+		    brew-disabled: true
+		  profiles:
+		    public:
+		      release:
+		        brew-disabled: false
+		        publish-now: true
+
+		## Monorepo
+
+		Multiple projects of the same repository are released independently by giving each
+		subdirectory its own '.sfreleaser' file with a distinct 'tag-prefix' (under 'global'
+		section). Releasing 'v1.2.3' then creates tag 'firehose-ethereum/v1.2.3' and only the
+		tags with the prefix are considered when resolving the latest released version:
+
+		  global:
+		    tag-prefix: firehose-ethereum/
+
+		With a prefix, the project (GitHub repository) defaults to the name of the repository's
+		root directory and the binary to the name of the subdirectory. The generated Goreleaser
+		config uses the 'monorepo' settings which require Goreleaser Pro, set a Goreleaser Pro
+		image with '--goreleaser-docker-image' and export its 'GORELEASER_KEY'.
 	`),
 	Flags(func(flags *pflag.FlagSet) {
 		flags.Bool("allow-dirty", false, "Perform release step even if Git is not clean, tries to configured used tool(s) to also allow dirty Git state")
 		flags.String("changelog-path", "CHANGELOG.md", "Path where to find the changelog file used to extract the release notes")
+		flags.String("notes-source", "changelog", "Where release notes come from, 'changelog' (changelog section of the version, or of its base version, defaulting to the first one), 'commits' (conventional commits since latest tag) or 'hybrid' (changelog notes with a 'Commits' appendix)")
 		flags.StringArray("pre-build-hooks", nil, "Set of pre build hooks to run before run the actual building steps, template your pre-hook with various injected variables, see long description of command for more details")
 		flags.StringArray("upload-extra-assets", nil, "If provided, add this extra asset file to the release, use a 'pre-build-hooks' to generate the file if needed")
+		flags.StringArray("post-release-hooks", nil, "Set of hooks to run once the release is created on GitHub (as a draft unless published right away) with its assets uploaded, see long description of command for the template variables")
+		flags.StringArray("post-publish-hooks", nil, "Set of hooks to run once the release is published on GitHub, see long description of command for the template variables")
+		flags.String("on-failure", "keep", "What to do with the draft release created on GitHub when the release fails or is interrupted, 'keep' (resume it later with --resume), 'delete-draft' (delete it with its uploaded assets) or 'prompt' (ask, keeps it in non-interactive mode)")
+		flags.StringArray("on-failure-hooks", nil, "Set of hooks to run when the release fails or is interrupted, after the draft release was handled according to --on-failure, see long description of command for the template variables")
 		flags.Bool("publish-now", false, "By default, publish the release to GitHub in draft mode, if the flag is used, the release is published as latest")
 		flags.String("goreleaser-docker-image", "goreleaser/goreleaser-cross:v1.25", "Full Docker image used to run Goreleaser tool (which perform Go builds and GitHub releases (in all languages))")
 		flags.Bool("no-binaries", false, "Skip building binaries completely; useful for library-only releases or when binaries are built through other means (cannot be used with library variant)")
+		flags.String("output", "text", "Output mode, 'text' (human readable) or 'json' (newline-delimited JSON events on standard output, see long description of command for more details)")
+		flags.Bool("dry-run", false, "Print the release plan (resolved configuration and every command that would be executed) without pushing, tagging or uploading anything")
+		flags.Bool("rc", false, "Release the first release candidate ('-rc.1', or the one following those already tagged) of the next version, the next version being the one recommended from the changelog and commits since latest tag")
+		flags.Bool("next-rc", false, "Release the next release candidate of the latest tag which must be a pre-release ('v1.0.0-rc.1' is followed by 'v1.0.0-rc.2')")
+		flags.Bool("non-interactive", false, "Never prompt, each question is answered by its flag and the release fails if the version cannot be determined, automatically enabled when environment variable 'CI' is 'true'")
+		flags.BoolP("yes", "y", false, "Alias of --non-interactive")
+		flags.Bool("delete-existing-draft", false, "Delete an existing draft release for the version without asking")
+		flags.Bool("git-pull", false, "Run 'git pull' without asking when the local branch is behind its remote")
+		flags.Bool("promote-changelog", false, "Promote the changelog 'Unreleased' section to the released version without asking")
+		flags.Bool("resume", false, "Resume a previously failed release from the step that failed, re-using the same version and release notes (progress is recorded in 'build/.release_state.json')")
 
 		// Brew Flags
 		flags.Bool("brew-disabled", false, "[Brew only] Disable Brew tap release completely, only applies for 'Golang'/'Application' types")
 		flags.String("brew-tap-repo", "homebrew-tap", "[Brew only] The GitHub project name of the tap, the repo owner is defined by 'owner' config value")
+		flags.Bool("brew-prerelease", false, "[Brew only] Update the Brew tap also for pre-release versions (like 'v1.0.0-rc.1'), by default the tap is only updated for stable versions")
 
 		// Rust Flags
 		flags.String("rust-cargo-publish-args", "", "[Rust only] The extra arguments to pass to 'cargo publish' when publishing, the tool might provide some default on its own, Bash rules are used to split the arguments from the string")
@@ -91,7 +257,7 @@ var ReleaseCmd = Command(release,
 
 		go func() {
 			<-sigs
-			cli.Exit(1)
+			failCommand("interrupted")
 		}()
 
 		if err := release(cmd, args); err != nil {
@@ -104,48 +270,91 @@ var ReleaseCmd = Command(release,
 		return nil
 	}),
 	OnCommandError(func(err error) {
-		fmt.Println("The release failed with the following error:")
-		fmt.Println()
-		fmt.Println(err.Error())
-		fmt.Println()
+		failCommand(err.Error())
+	}),
+)
+
+// printReleaseFailure explains the release failure `message`, with the known issues matching it
+// or the output of the commands that failed.
+func printReleaseFailure(message string) {
+	fmt.Println()
+	fmt.Println("The release failed with the following error:")
+	fmt.Println()
+	fmt.Println(message)
+	fmt.Println()
 
+	if !printKnownIssuesDiagnosis(message + "\n" + strings.Join(failedCommandOutputs, "\n")) {
 		fmt.Println("If the error is not super clear, you can use 'sfreleaser doctor' which")
 		fmt.Println("list common errors and how to fix them.")
-
-		cli.Exit(1)
-	}),
-)
+	}
+	fmt.Println()
+	fmt.Println("Once fixed, you can retry from the failed step with 'sfreleaser release --resume'.")
+}
 
 func release(cmd *cobra.Command, args []string) error {
+	mustSetupOutput(cmd)
+	setupCommandFailure(printReleaseFailure)
+
 	global := mustGetGlobal(cmd)
 	release := &ReleaseModel{Version: ""}
 	if len(args) > 0 {
-		release.Version = args[0]
-		cli.NoError(validVersion(release.Version), "invalid version")
+		cli.NoError(validVersion(args[0], global.TagPrefix), "invalid version")
+		release.Version = global.TrimTagPrefix(args[0])
 	}
 
 	allowDirty := sflags.MustGetBool(cmd, "allow-dirty")
+	dryRun := sflags.MustGetBool(cmd, "dry-run")
+	resume := sflags.MustGetBool(cmd, "resume")
+	releaseCandidate := sflags.MustGetBool(cmd, "rc")
+	nextReleaseCandidate := sflags.MustGetBool(cmd, "next-rc")
 	changelogPath := global.ResolveFile(sflags.MustGetString(cmd, "changelog-path"))
+	notesSource, err := parseReleaseNotesSource(sflags.MustGetString(cmd, "notes-source"))
+	cli.NoError(err, "Invalid 'notes-source' value")
 	goreleaserDockerImage := sflags.MustGetString(cmd, "goreleaser-docker-image")
 	publishNow := sflags.MustGetBool(cmd, "publish-now")
-	preBuildHooks := sflags.MustGetStringArray(cmd, "pre-build-hooks")
+	deleteExistingDraft := sflags.MustGetBool(cmd, "delete-existing-draft")
+	gitPull := sflags.MustGetBool(cmd, "git-pull")
+	promoteChangelog := sflags.MustGetBool(cmd, "promote-changelog")
+	nonInteractive = sflags.MustGetBool(cmd, "non-interactive") || sflags.MustGetBool(cmd, "yes") || isCIEnvironment()
+	preBuildHooks := mustGetHooks(cmd, "pre-build-hooks")
 	uploadExtraAssets := sflags.MustGetStringArray(cmd, "upload-extra-assets")
+	postReleaseHooks := mustGetHooks(cmd, "post-release-hooks")
+	postPublishHooks := mustGetHooks(cmd, "post-publish-hooks")
+	onFailure, err := parseReleaseFailurePolicy(sflags.MustGetString(cmd, "on-failure"))
+	cli.NoError(err, "Invalid 'on-failure' value")
+	onFailureHooks := mustGetHooks(cmd, "on-failure-hooks")
 
 	// Deprecated, use uploadExtraAsset instead with a custom pre build hook for packaging
 	uploadSubstreamsSPKG := sflags.MustGetString(cmd, "upload-substreams-spkg")
 
 	release.populate(cmd, global)
 
+	cli.Ensure(!releaseCandidate || !nextReleaseCandidate, "Flags --rc and --next-rc are mutually exclusive")
+	cli.Ensure(release.Version == "" || (!releaseCandidate && !nextReleaseCandidate), "Flags --rc and --next-rc cannot be used when the version to release is provided")
+
 	zlog.Debug("starting 'sfreleaser release'",
 		zap.Inline(global),
 		zap.Bool("allow_dirty", allowDirty),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("resume", resume),
+		zap.Bool("rc", releaseCandidate),
+		zap.Bool("next_rc", nextReleaseCandidate),
 		zap.String("changelog_path", changelogPath),
+		zap.String("notes_source", string(notesSource)),
 		zap.String("goreleaser_docker_image", goreleaserDockerImage),
 		zap.Bool("publish_now", publishNow),
-		zap.Strings("pre_build_hooks", preBuildHooks),
+		zap.Bool("non_interactive", nonInteractive),
+		zap.Bool("delete_existing_draft", deleteExistingDraft),
+		zap.Bool("git_pull", gitPull),
+		zap.Bool("promote_changelog", promoteChangelog),
+		zap.Reflect("pre_build_hooks", preBuildHooks),
 		zap.String("upload", uploadSubstreamsSPKG),
 		zap.String("upload_substreams_spkg (deprecated)", uploadSubstreamsSPKG),
 		zap.Strings("upload_extra_assets", uploadExtraAssets),
+		zap.Reflect("post_release_hooks", postReleaseHooks),
+		zap.Reflect("post_publish_hooks", postPublishHooks),
+		zap.String("on_failure", string(onFailure)),
+		zap.Reflect("on_failure_hooks", onFailureHooks),
 		zap.Reflect("release_model", release),
 	)
 
@@ -156,28 +365,87 @@ func release(cmd *cobra.Command, args []string) error {
 
 	verifyTools()
 
+	if dryRun {
+		activePlan = newExecutionPlan()
+	}
+
+	buildDirectory := "build"
+	envFilePath := filepath.Join(buildDirectory, ".env.release")
+	releaseNotesPath := filepath.Join(buildDirectory, ".release_notes.md")
+	progressPath := filepath.Join(buildDirectory, ".release_state.json")
+	summaryPath := filepath.Join(buildDirectory, ".release_summary.json")
+
+	var progress *releaseProgress
+	if resume {
+		progress = mustLoadReleaseProgress(progressPath)
+		if release.Version != "" && release.Version != progress.Version {
+			cli.Quit("Cannot resume release of %q, the release in progress is for version %q", release.Version, progress.Version)
+		}
+
+		release.Version = progress.Version
+		fmt.Printf("Resuming release of %q started at %s\n", progress.Version, progress.StartedAt.Format(time.RFC3339))
+	}
+
 	if release.Version == "" {
-		release.Version = promptVersion(changelogPath, resolveGitRemote(global))
+		release.Version = promptVersion(changelogPath, resolveGitRemote(global), global.TagPrefix, releaseCandidate, nextReleaseCandidate)
 	}
 
+	release.setVersion(release.Version)
+
 	// For simplicity in the code below
 	version := release.Version
 
-	ensureGitHubReleaseValid(global, version)
+	if progress == nil {
+		progress = newReleaseProgress(progressPath, version)
+	}
+
+	emitEvent(&outputEvent{Type: eventStarted, Repository: global.Owner + "/" + global.Project, Version: version})
 
-	delay := 3 * time.Second
-	fmt.Printf("Releasing %q (Draft: %t, Publish Now: %t) in %s...\n", version, !publishNow, publishNow, delay)
-	time.Sleep(delay)
+	cli.NoError(os.MkdirAll(buildDirectory, os.ModePerm), "Unable to create build directory")
+	client := newGitHubClient(configureGitHubTokenEnvFile(envFilePath))
+
+	failure := &releaseFailure{
+		policy:   onFailure,
+		hooks:    onFailureHooks,
+		client:   client,
+		global:   global,
+		release:  release,
+		buildDir: buildDirectory,
+		progress: progress,
+	}
 
-	ensureGitSync(global)
+	if !dryRun {
+		failure.register()
+	}
 
-	buildDirectory := "build"
-	envFilePath := filepath.Join(buildDirectory, ".env.release")
-	releaseNotesPath := filepath.Join(buildDirectory, ".release_notes.md")
+	// When resuming after goreleaser completed, the draft release is ours and must be kept
+	if !progress.IsCompleted(releaseStepGoreleaser) {
+		ensureGitHubReleaseValid(client, global, version, deleteExistingDraft)
+	}
 
-	cli.NoError(os.MkdirAll(buildDirectory, os.ModePerm), "Unable to create build directory")
-	configureGitHubTokenEnvFile(envFilePath)
-	cli.WriteFile(releaseNotesPath, "%s", readReleaseNotes(changelogPath))
+	if dryRun {
+		fmt.Printf("Planning release of %q (Draft: %t, Publish Now: %t)...\n", version, !publishNow, publishNow)
+	} else if nonInteractive {
+		fmt.Printf("Releasing %q (Draft: %t, Publish Now: %t)...\n", version, !publishNow, publishNow)
+	} else {
+		delay := 3 * time.Second
+		fmt.Printf("Releasing %q (Draft: %t, Publish Now: %t) in %s...\n", version, !publishNow, publishNow, delay)
+		time.Sleep(delay)
+	}
+
+	progress.Run(releaseStepGitSync, func() {
+		ensureGitSync(global, gitPull)
+	})
+
+	if progress.IsCompleted(releaseStepChangelogPromote) || promptPromoteChangelog(changelogPath, version, promoteChangelog) {
+		progress.Run(releaseStepChangelogPromote, func() {
+			promoteChangelogForRelease(global, changelogPath, version)
+		})
+	}
+
+	progress.Run(releaseStepReleaseNotes, func() {
+		cli.WriteFile(releaseNotesPath, "%s", resolveReleaseNotes(notesSource, changelogPath, version, global))
+	})
 
 	// By doing this after creating the build directory and release notes, we ensure
 	// that those are ignored, the user will need to ignore them to process (or --allow-dirty).
@@ -186,30 +454,38 @@ func release(cmd *cobra.Command, args []string) error {
 	}
 
 	if uploadSubstreamsSPKG != "" {
+		replacementHooks, replacementAssets := substreamsSPKGReplacement(uploadSubstreamsSPKG)
+		if len(replacementHooks) > 0 {
+			zlog.Warn(fmt.Sprintf(`the 'upload-substreams-spkg' flag is deprecated, use a custom 'pre-build-hooks: [%q]' to package it and 'upload-extra-assets: [%q]' (under 'release' section) to attach it to the release, 'sfreleaser config migrate' performs this rewrite`, replacementHooks[0], replacementAssets[0]))
+		} else {
+			zlog.Warn(fmt.Sprintf("the 'upload-substreams-spkg' flag is deprecated, use a custom 'upload-extra-assets: [%q]' (under 'release' section) to attach it to the release, 'sfreleaser config migrate' performs this rewrite", replacementAssets[0]))
+		}
+
 		if !strings.HasSuffix(uploadSubstreamsSPKG, ".spkg") {
 			manifestFile := uploadSubstreamsSPKG
 			uploadSubstreamsSPKG = filepath.Join("{{ .buildDir }}", global.Project+"-"+version+".spkg")
 
-			zlog.Warn(fmt.Sprintf(`the 'upload-substreams-spkg' flag is deprecated, use a custom "pre-build-hooks: ['substreams pack -o "{{ .buildDir }}/substreams-{{ .release.Version }}.spkg" %s'] to package it and 'upload-extra-assets: ['{{ .buildDir }}/substreams-{{ .version }}.spkg'] to attach it to the release`, manifestFile))
-			preBuildHooks = append(preBuildHooks, fmt.Sprintf("substreams pack -o '%s' '%s'", global.ResolveFile(uploadSubstreamsSPKG), global.ResolveFile(manifestFile)))
-		} else {
-			zlog.Warn(fmt.Sprintf("the 'upload-substreams-spkg' flag is deprecated, use a custom 'upload-extra-assets: [%s]' (under 'release' section) to attach it to the release", uploadSubstreamsSPKG))
+			preBuildHooks = append(preBuildHooks, commandHooks(fmt.Sprintf("substreams pack -o '%s' '%s'", global.ResolveFile(uploadSubstreamsSPKG), global.ResolveFile(manifestFile)))...)
 		}
 
 		uploadExtraAssets = append(uploadExtraAssets, uploadSubstreamsSPKG)
 	}
 
 	if len(preBuildHooks) > 0 {
-		fmt.Println()
-		fmt.Printf("Executing %d pre-build hook(s)\n", len(preBuildHooks))
-		executeHooks(preBuildHooks, buildDirectory, global, release)
+		progress.Run(releaseStepPreBuildHooks, func() {
+			fmt.Println()
+			fmt.Printf("Executing %d pre-build hook(s)\n", len(preBuildHooks))
+			executeHooks(preBuildHooks, buildDirectory, global, release)
+		})
 	}
 
 	// Ensure Substreams package (.spkg) is built when releasing Substreams variant
 	if global.Language == LanguageRust && global.Variant == VariantSubstreams {
-		fmt.Println()
-		fmt.Println("Building Substreams package (.spkg)")
-		buildSubstreamsPackage(global)
+		progress.Run(releaseStepSubstreamsPackage, func() {
+			fmt.Println()
+			fmt.Println("Building Substreams package (.spkg)")
+			buildSubstreamsPackage(global)
+		})
 	}
 
 	if len(uploadExtraAssets) > 0 {
@@ -227,14 +503,34 @@ func release(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Println()
-	fmt.Println("Creating temporary tag so that goreleaser can work properly")
-	run("git tag", version)
+	if !progress.IsCompleted(releaseStepGoreleaser) {
+		if progress.IsCompleted(releaseStepTag) && !gitTagPointsAtHead(global.Tag(version)) {
+			// The temporary tag of the previous run is gone (or moved), it must be created again
+			progress.Reset(releaseStepTag)
+		}
 
-	cli.ExitHandler(deleteTagExitHandlerID, func(_ int) {
-		zlog.Debug("Deleting local temporary tag")
-		runSilent("git tag -d", version)
-	})
+		if !progress.IsCompleted(releaseStepTag) && gitTagPointsAtHead(global.Tag(version)) {
+			// Happens when the release is triggered by pushing the tag, like in the workflow
+			// written by 'sfreleaser init --with-workflow'
+			fmt.Println()
+			fmt.Printf("Tag %q already exists on current commit, using it\n", global.Tag(version))
+		} else {
+			progress.Run(releaseStepTag, func() {
+				fmt.Println()
+				fmt.Println("Creating temporary tag so that goreleaser can work properly")
+				run("git tag", global.Tag(version))
+			})
+
+			// Also registered when resuming, the temporary tag created by the previous run is ours
+			if !dryRun {
+				cli.ExitHandler(deleteTagExitHandlerID, func(_ int) {
+					zlog.Debug("Deleting local temporary tag")
+					runSilent("git tag -d", global.Tag(version))
+					progress.Reset(releaseStepTag)
+				})
+			}
+		}
+	}
 
 	gitHubRelease := &GitHubReleaseModel{
 		AllowDirty:           allowDirty,
@@ -244,17 +540,85 @@ func release(cmd *cobra.Command, args []string) error {
 		ReleaseNotesPath:     releaseNotesPath,
 	}
 
-	releaseGithub(global, release, gitHubRelease)
+	// From now on, a draft release for the version is ours (checked by 'ensureGitHubReleaseValid' above)
+	failure.draftCreated = true
+
+	progress.Run(releaseStepGoreleaser, func() {
+		releaseGithub(client, global, release, gitHubRelease)
+	})
 
 	for _, extraAsset := range uploadExtraAssets {
-		fmt.Printf("Uploading asset file %q to release\n", filepath.Base(extraAsset))
-		run("gh release upload", version, "--repo", global.Owner+"/"+global.Project, "'"+extraAsset+"'")
+		progress.Run(releaseStepUploadAsset(filepath.Base(extraAsset)), func() {
+			fmt.Printf("Uploading asset file %q to release\n", filepath.Base(extraAsset))
+			uploadReleaseAsset(client, global, version, extraAsset)
+		})
+	}
+
+	if len(postReleaseHooks) > 0 {
+		progress.Run(releaseStepPostReleaseHooks, func() {
+			fmt.Println()
+			fmt.Printf("Executing %d post-release hook(s)\n", len(postReleaseHooks))
+			executeHooksWithModel(postReleaseHooks, releaseHookModel(client, global, release, buildDirectory, false))
+		})
+	}
+
+	publish := func() {
+		publishReleaseNow(client, global, release, progress)
+
+		if len(postPublishHooks) > 0 {
+			progress.Run(releaseStepPostPublishHooks, func() {
+				fmt.Println()
+				fmt.Printf("Executing %d post-publish hook(s)\n", len(postPublishHooks))
+				executeHooksWithModel(postPublishHooks, releaseHookModel(client, global, release, buildDirectory, true))
+			})
+		}
+
+		// The release is published, there is nothing left to resume
+		progress.Finish()
+	}
+
+	if dryRun {
+		if publishNow {
+			publish()
+		}
+
+		if release.Prerelease {
+			activePlan.Detail("Version", "%s (pre-release)", version)
+		} else {
+			activePlan.Detail("Version", "%s", version)
+		}
+		activePlan.Detail("Repository", "%s/%s", global.Owner, global.Project)
+		if activeProfile != "" {
+			activePlan.Detail("Profile", "%s", activeProfile)
+		}
+		activePlan.Detail("Git remote", "%s", resolveGitRemote(global))
+		activePlan.Detail("Mode", "%s", releaseModeLabel(publishNow))
+		activePlan.Detail("On failure", "%s (%d hook(s))", onFailure, len(onFailureHooks))
+		activePlan.Detail("Goreleaser config", "%s", gitHubRelease.GoreleaserConfigPath)
+		activePlan.Detail("Goreleaser image", "%s", goreleaserDockerImage)
+		if releaseNotes := cli.ReadFile(releaseNotesPath); releaseNotes == "" {
+			activePlan.Detail("Release notes", "%s (empty, no %s notes found)", releaseNotesPath, notesSource)
+		} else {
+			activePlan.Detail("Release notes", "%s (%d lines from %s)", releaseNotesPath, len(getLines(releaseNotes)), notesSource)
+		}
+		if resume {
+			activePlan.Detail("Resumed steps", "%d already completed", len(progress.Steps))
+		}
+		for _, extraAsset := range uploadExtraAssets {
+			activePlan.Detail("Extra asset", "%s", extraAsset)
+		}
+
+		fmt.Println()
+		fmt.Print(activePlan)
+
+		emitEvent(&outputEvent{Type: eventCompleted, Version: version})
+		return nil
 	}
 
-	releaseURL := releaseURL(global, version)
+	releaseURL := releaseURL(client, global, version)
 
 	if publishNow {
-		publishReleaseNow(global, release)
+		publish()
 	} else {
 		fmt.Println()
 		fmt.Println(dedent(`
@@ -267,22 +631,24 @@ func release(cmd *cobra.Command, args []string) error {
 			and then press the 'Publish release' green button (scroll down to the bottom
 			of the page.
 
-			You can also publish from the GitHub CLI directly:
+			You can also publish from the GitHub CLI (https://cli.github.com/) directly:
 
 			  gh release edit %s --draft=false
 
 			If something is wrong, you can delete the release from GitHub and try again by
 			doing 'gh release delete %s'.
-		`, releaseURL, version, version))
+		`, releaseURL, global.Tag(version), global.Tag(version)))
 
-		fmt.Println()
-		if yes, _ := cli.PromptConfirm("View release right now?"); yes {
-			reviewRelease(releaseURL)
+		if !nonInteractive {
+			fmt.Println()
+			if yes, _ := cli.PromptConfirm("View release right now?"); yes {
+				reviewRelease(releaseURL)
+			}
 		}
 
 		fmt.Println()
-		if yes, _ := cli.PromptConfirm("Publish release right now?"); yes {
-			publishReleaseNow(global, release)
+		if confirm("Publish release right now?", "publish-now", false) {
+			publish()
 		} else {
 			if global.Language == LanguageRust {
 				switch global.Variant {
@@ -297,32 +663,18 @@ func release(cmd *cobra.Command, args []string) error {
 		fmt.Println("Completed")
 	}
 
+	writeReleaseSummary(client, global, version, summaryPath, nonInteractive)
+	emitEvent(&outputEvent{Type: eventCompleted, Version: version})
+
 	return nil
 }
 
-func executeHooks(hooks []string, buildDir string, global *GlobalModel, release *ReleaseModel) {
-	model := map[string]any{
-		"global":   global,
-		"release":  release,
-		"buildDir": buildDir,
-	}
-
-	for _, hook := range hooks {
-		executeHook(hook, model)
+func releaseModeLabel(publishNow bool) string {
+	if publishNow {
+		return "publish now"
 	}
-}
-
-func executeHook(command string, model map[string]any) {
-	parsed, err := template.New("hook").Parse(command)
-	cli.NoError(err, "Parse hook template %q", command)
-
-	// Hook length + 10% as the initial buffer size
-	out := bytes.NewBuffer(make([]byte, 0, int(float64(len(command))*1.10)))
-	cli.NoError(parsed.Execute(out, model), "Unable to execute template")
 
-	zlog.Debug("hook templated", zap.Stringer("hook", out))
-
-	run(out.String())
+	return "draft"
 }
 
 func resolveAsset(asset string, global *GlobalModel, model map[string]any) string {
@@ -338,32 +690,25 @@ func resolveAsset(asset string, global *GlobalModel, model map[string]any) strin
 	return global.ResolveFile(out.String())
 }
 
-func verifyTools() {
-	ensureCommandExist("docker", cli.Dedent(`
-		The 'docker' utility (https://docs.docker.com/get-docker/) is perform the
-		release.
+var dockerMissingMessage = cli.Dedent(`
+	The 'docker' utility (https://docs.docker.com/get-docker/) is perform the
+	release.
 
-		Install it via https://docs.docker.com/get-docker/. Ensure you have it enough
-		resources allocated to it. You should use the fastest available options for your
-		system. You should also allocate minimally 4 CPU and 8GiB of RAM.
-	`))
+	Install it via https://docs.docker.com/get-docker/. Ensure you have it enough
+	resources allocated to it. You should use the fastest available options for your
+	system. You should also allocate minimally 4 CPU and 8GiB of RAM.
+`)
 
-	ensureCommandRunSuccesfully("docker info", cli.Dedent(`
-		Ensure that your Docker Engine is currently running, it seems it's not running
-		right now because the command 'docker info' failed.
+var dockerNotRunningMessage = cli.Dedent(`
+	Ensure that your Docker Engine is currently running, it seems it's not running
+	right now because the command 'docker info' failed.
 
-		Try running the command 'docker info' locally to see the output. Ensure that it
-		execute successuflly and exits with a 0 exit code (run 'echo $?' right after
-		execution of the 'docker info' command to get its exit code).
-	`))
+	Try running the command 'docker info' locally to see the output. Ensure that it
+	execute successuflly and exits with a 0 exit code (run 'echo $?' right after
+	execution of the 'docker info' command to get its exit code).
+`)
 
-	ensureCommandExist("gh", cli.Dedent(`
-		The GitHub CLI utility (https://cli.github.com/) is required to obtain
-		information about the current draft release.
-
-		Install via brew with 'brew install gh' or refer https://github.com/cli/cli#installation
-		otherwise.
-
-		Don't forget to activate link with GitHub by doing 'gh auth login'.
-	`))
+func verifyTools() {
+	ensureCommandExist("docker", dockerMissingMessage)
+	ensureCommandRunSuccesfully("docker info", dockerNotRunningMessage)
 }
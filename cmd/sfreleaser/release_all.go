@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/streamingfast/cli"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultReleaseAllManifest = ".sfreleaser-workspace"
+
+var ReleaseAllCmd = Command(releaseAll,
+	"release-all [<manifest>] [-- <release flags>...]",
+	"Release a chain of projects, in order, from a workspace manifest listing their local checkouts",
+	ArbitraryArgs(),
+	Flags(func(flags *pflag.FlagSet) {
+		flags.Bool("dry-run", false, "Print the release plan of every project without pushing, tagging or uploading anything")
+		flags.String("from", "", "Start the batch at the release with this 'path' (as written in the manifest), to continue a batch that stopped on a failure")
+	}),
+	Description(`
+		Releases each project listed in the workspace manifest (defaults to '.sfreleaser-workspace'),
+		in order, by running 'sfreleaser release' in its checkout so that its own '.sfreleaser'
+		config file is used. The batch stops at the first failing release and a table of the
+		released versions and their release URLs is printed at the end.
+
+		The manifest is a YAML file, relative paths are resolved from the manifest directory:
+
+		  releases:
+		    # Released first since the others depend on it
+		    - path: ../substreams-sdk
+		      version: v1.2.0
+		    - path: ../firehose-core
+		      # Extra 'sfreleaser release' flags for this project only
+		      args: ["--publish-now"]
+		    - path: ../firehose-ethereum
+
+		Releases run non-interactively ('--non-interactive'), standard output of each release being
+		used to collect its events. When 'version' is not set, it must be resolvable without a
+		prompt, from the changelog or with '--rc'/'--next-rc'. Questions are answered by their
+		flag (e.g. '--publish-now', '--delete-existing-draft', '--git-pull'), 'no' otherwise. Flags
+		after '--' are passed to every 'sfreleaser release' invocation.
+
+		Use '--dry-run' to print the release plan of every project, nothing is released.
+
+		When a release fails, fix it (possibly with 'sfreleaser release --resume' in its checkout)
+		and continue the batch with the following release using '--from <path>'.
+	`),
+	ExamplePrefixed("sfreleaser release-all", `
+		# Release the projects of '.sfreleaser-workspace' found in the current directory
+		# (identical to 'sfreleaser release-all .sfreleaser-workspace')
+
+		# Print the release plan of the whole batch
+		--dry-run
+
+		# Release and publish right away every project of another manifest
+		../releases.yaml -- --publish-now
+
+		# Continue the batch from a given project
+		--from ../firehose-ethereum
+	`),
+)
+
+type releaseAllManifest struct {
+	Releases []*releaseAllEntry `yaml:"releases"`
+}
+
+type releaseAllEntry struct {
+	// Path is the local checkout of the project, relative paths are resolved from the manifest directory
+	Path string `yaml:"path"`
+	// Version is optional, when empty it's resolved like 'sfreleaser release' does
+	Version string   `yaml:"version"`
+	Args    []string `yaml:"args"`
+
+	// directory is the resolved [Path]
+	directory string
+}
+
+func releaseAll(cmd *cobra.Command, args []string) error {
+	var releaseArgs []string
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		args, releaseArgs = args[:dash], args[dash:]
+	}
+
+	if len(args) > 1 {
+		return fmt.Errorf("accepts at most one manifest argument, got %d, use '--' before flags passed to 'sfreleaser release'", len(args))
+	}
+
+	manifestPath := defaultReleaseAllManifest
+	if len(args) > 0 {
+		manifestPath = args[0]
+	}
+
+	dryRun := sflags.MustGetBool(cmd, "dry-run")
+	from := sflags.MustGetString(cmd, "from")
+
+	manifest, err := readReleaseAllManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	start, err := manifest.indexOf(from)
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to resolve sfreleaser executable: %w", err)
+	}
+
+	zlog.Debug("starting 'sfreleaser release-all'",
+		zap.String("manifest", manifestPath),
+		zap.Int("releases", len(manifest.Releases)),
+		zap.Strings("release_args", releaseArgs),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	// Interrupting reaches the running release (same process group) which cleans up after itself
+	// and fails, the batch then stops and the table is still printed.
+	signal.Notify(make(chan os.Signal, 1), syscall.SIGINT, syscall.SIGTERM)
+
+	results := make([]*releaseAllResult, len(manifest.Releases))
+	for i, entry := range manifest.Releases {
+		status := "pending"
+		if i < start {
+			status = "skipped"
+		}
+
+		results[i] = &releaseAllResult{Path: entry.Path, Status: status}
+	}
+
+	var failed *releaseAllEntry
+	var failure error
+	for i := start; i < len(manifest.Releases); i++ {
+		entry := manifest.Releases[i]
+
+		fmt.Println()
+		fmt.Printf("[%d/%d] Releasing %s\n", i+1, len(manifest.Releases), entry.Path)
+
+		if err := runReleaseAllEntry(executable, entry, releaseAllArguments(entry, dryRun, releaseArgs), results[i]); err != nil {
+			failed, failure = entry, fmt.Errorf("release of %s failed: %w", entry.Path, err)
+			break
+		}
+	}
+
+	fmt.Println()
+	if dryRun {
+		fmt.Println("Release plan summary (dry-run, nothing was released):")
+	} else {
+		fmt.Println("Release summary:")
+	}
+
+	printReleaseAllResults(os.Stdout, results)
+
+	if failed != nil {
+		fmt.Println()
+		fmt.Printf("Once fixed, continue the batch with 'sfreleaser release-all --from %s' (or from the release following it if it was completed manually).\n", failed.Path)
+	}
+
+	return failure
+}
+
+// indexOf returns the index of the release whose path is `path`, 0 when `path` is empty.
+func (m *releaseAllManifest) indexOf(path string) (int, error) {
+	if path == "" {
+		return 0, nil
+	}
+
+	for i, entry := range m.Releases {
+		if entry.Path == path || filepath.Clean(entry.Path) == filepath.Clean(path) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no release with path %q in workspace manifest", path)
+}
+
+func readReleaseAllManifest(manifestPath string) (*releaseAllManifest, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workspace manifest %q: %w", manifestPath, err)
+	}
+
+	manifest := &releaseAllManifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid workspace manifest %q: %w", manifestPath, err)
+	}
+
+	if len(manifest.Releases) == 0 {
+		return nil, fmt.Errorf("workspace manifest %q has no 'releases' defined", manifestPath)
+	}
+
+	// Everything is checked before releasing anything, a typo should not stop the batch midway
+	for i, entry := range manifest.Releases {
+		if entry.Path == "" {
+			return nil, fmt.Errorf("workspace manifest %q release #%d has no 'path' defined", manifestPath, i+1)
+		}
+
+		entry.directory = entry.Path
+		if !filepath.IsAbs(entry.directory) {
+			entry.directory = filepath.Join(filepath.Dir(manifestPath), entry.Path)
+		}
+
+		if !cli.FileExists(filepath.Join(entry.directory, ".sfreleaser")) {
+			return nil, fmt.Errorf("workspace manifest %q release %s: no '.sfreleaser' config file found in %q", manifestPath, entry.Path, entry.directory)
+		}
+	}
+
+	return manifest, nil
+}
+
+// releaseAllArguments returns the 'sfreleaser' arguments releasing `entry`, the events of the
+// release are emitted on standard output to collect the released version and its URL. The release
+// never prompts since standard output is not a terminal, a prompt would never be displayed.
+func releaseAllArguments(entry *releaseAllEntry, dryRun bool, releaseArgs []string) []string {
+	arguments := []string{"release", "--output=json", "--non-interactive"}
+	if dryRun {
+		arguments = append(arguments, "--dry-run")
+	}
+
+	arguments = append(arguments, releaseArgs...)
+	arguments = append(arguments, entry.Args...)
+
+	if entry.Version != "" {
+		arguments = append(arguments, entry.Version)
+	}
+
+	return arguments
+}
+
+func runReleaseAllEntry(executable string, entry *releaseAllEntry, arguments []string, result *releaseAllResult) error {
+	command := exec.Command(executable, arguments...)
+	command.Dir = entry.directory
+	command.Stdin = os.Stdin
+	// In json output mode, everything but the events is written to standard error
+	command.Stderr = os.Stderr
+
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("unable to read release output: %w", err)
+	}
+
+	zlog.Debug("running release", zap.String("directory", entry.directory), zap.Strings("arguments", arguments))
+	if err := command.Start(); err != nil {
+		return fmt.Errorf("unable to start release: %w", err)
+	}
+
+	result.Status = "failed"
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		event := &outputEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			// Should not happen, standard output is reserved for events in json output mode
+			fmt.Println(scanner.Text())
+			continue
+		}
+
+		result.apply(event)
+	}
+
+	if err := command.Wait(); err != nil {
+		result.Status = "failed"
+		if result.Error != "" {
+			return errors.New(result.Error)
+		}
+
+		return err
+	}
+
+	if result.Status == "failed" {
+		// Completed without reporting its outcome, should not happen
+		result.Status = "completed"
+	}
+
+	return nil
+}
+
+type releaseAllResult struct {
+	Path       string
+	Repository string
+	Version    string
+	URL        string
+	Status     string
+	Error      string
+}
+
+// apply updates the result from an event emitted by the release.
+func (r *releaseAllResult) apply(event *outputEvent) {
+	switch event.Type {
+	case eventStarted:
+		r.Repository = event.Repository
+		r.Version = event.Version
+
+	case eventReleaseURL:
+		r.URL = event.URL
+		if event.Published != nil && *event.Published {
+			r.Status = "published"
+		} else {
+			r.Status = "draft"
+		}
+
+	case eventCompleted:
+		if r.URL == "" {
+			// Dry-run completes without a release
+			r.Status = "planned"
+		}
+
+	case eventError:
+		r.Status = "failed"
+		r.Error = event.Message
+	}
+}
+
+func printReleaseAllResults(out io.Writer, results []*releaseAllResult) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "  PATH\tREPOSITORY\tVERSION\tSTATUS\tURL")
+
+	for _, result := range results {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n", result.Path, orDash(result.Repository), orDash(result.Version), result.Status, orDash(result.URL))
+	}
+
+	writer.Flush()
+}
+
+func orDash(in string) string {
+	if in == "" {
+		return "-"
+	}
+
+	return in
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readReleaseAllManifest(t *testing.T) {
+	workspace := t.TempDir()
+	for _, project := range []string{"library", "application"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(workspace, project), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(workspace, project, ".sfreleaser"), nil, os.ModePerm))
+	}
+
+	writeManifest := func(t *testing.T, content string) string {
+		path := filepath.Join(workspace, "releases", ".sfreleaser-workspace")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		manifest, err := readReleaseAllManifest(writeManifest(t, dedent(`
+			releases:
+			- path: ../library
+			  version: v1.2.0
+			- path: `+filepath.Join(workspace, "application")+`
+			  args: ["--publish-now"]
+		`)))
+		require.NoError(t, err)
+		require.Len(t, manifest.Releases, 2)
+
+		assert.Equal(t, &releaseAllEntry{Path: "../library", Version: "v1.2.0", directory: filepath.Join(workspace, "library")}, manifest.Releases[0])
+		assert.Equal(t, &releaseAllEntry{Path: filepath.Join(workspace, "application"), Args: []string{"--publish-now"}, directory: filepath.Join(workspace, "application")}, manifest.Releases[1])
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty", "", "has no 'releases' defined"},
+		{"unknown field", "releases:\n- path: ../library\n  versions: v1.0.0", "field versions not found"},
+		{"missing path", "releases:\n- path: ../library\n- version: v1.0.0", "release #2 has no 'path' defined"},
+		{"missing config file", "releases:\n- path: ../library\n- path: ../other", "release ../other: no '.sfreleaser' config file found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readReleaseAllManifest(writeManifest(t, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_releaseAllManifest_indexOf(t *testing.T) {
+	manifest := &releaseAllManifest{Releases: []*releaseAllEntry{{Path: "../library"}, {Path: "../application"}}}
+
+	index, err := manifest.indexOf("")
+	require.NoError(t, err)
+	assert.Equal(t, 0, index)
+
+	index, err = manifest.indexOf("../application/")
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	_, err = manifest.indexOf("../other")
+	assert.EqualError(t, err, `no release with path "../other" in workspace manifest`)
+}
+
+func Test_releaseAllArguments(t *testing.T) {
+	entry := &releaseAllEntry{Path: "../library", Version: "v1.2.0", Args: []string{"--brew-disabled"}}
+
+	assert.Equal(t, []string{"release", "--output=json", "--non-interactive", "--publish-now", "--brew-disabled", "v1.2.0"}, releaseAllArguments(entry, false, []string{"--publish-now"}))
+	assert.Equal(t, []string{"release", "--output=json", "--non-interactive", "--dry-run"}, releaseAllArguments(&releaseAllEntry{Path: "../application"}, true, nil))
+}
+
+func Test_releaseAllResult_apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []*outputEvent
+		want   *releaseAllResult
+	}{
+		{
+			"published",
+			[]*outputEvent{
+				{Type: eventStarted, Repository: "streamingfast/library", Version: "v1.2.0"},
+				{Type: eventStepStarted, Step: "goreleaser"},
+				{Type: eventReleaseURL, Version: "v1.2.0", URL: "https://github.com/streamingfast/library/releases/tag/v1.2.0", Published: ptr(true)},
+				{Type: eventCompleted, Version: "v1.2.0"},
+			},
+			&releaseAllResult{Repository: "streamingfast/library", Version: "v1.2.0", URL: "https://github.com/streamingfast/library/releases/tag/v1.2.0", Status: "published"},
+		},
+		{
+			"draft",
+			[]*outputEvent{
+				{Type: eventStarted, Repository: "streamingfast/library", Version: "v1.2.0"},
+				{Type: eventReleaseURL, URL: "https://github.com/streamingfast/library/releases/tag/untagged-1", Published: ptr(false)},
+				{Type: eventCompleted, Version: "v1.2.0"},
+			},
+			&releaseAllResult{Repository: "streamingfast/library", Version: "v1.2.0", URL: "https://github.com/streamingfast/library/releases/tag/untagged-1", Status: "draft"},
+		},
+		{
+			"dry-run",
+			[]*outputEvent{
+				{Type: eventStarted, Repository: "streamingfast/library", Version: "v1.2.0"},
+				{Type: eventCompleted, Version: "v1.2.0"},
+			},
+			&releaseAllResult{Repository: "streamingfast/library", Version: "v1.2.0", Status: "planned"},
+		},
+		{
+			"failed",
+			[]*outputEvent{
+				{Type: eventStarted, Repository: "streamingfast/library", Version: "v1.2.0"},
+				{Type: eventError, Message: "goreleaser failed"},
+			},
+			&releaseAllResult{Repository: "streamingfast/library", Version: "v1.2.0", Status: "failed", Error: "goreleaser failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &releaseAllResult{}
+			for _, event := range tt.events {
+				result.apply(event)
+			}
+
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_printReleaseAllResults(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	printReleaseAllResults(out, []*releaseAllResult{
+		{Path: "../library", Repository: "streamingfast/library", Version: "v1.2.0", URL: "https://github.com/streamingfast/library/releases/tag/v1.2.0", Status: "published"},
+		{Path: "../application", Repository: "streamingfast/application", Version: "v0.5.0", Status: "failed"},
+		{Path: "../other", Status: "pending"},
+	})
+
+	assert.Equal(t, indentAllLines(dedent(`
+		PATH            REPOSITORY                 VERSION  STATUS     URL
+		../library      streamingfast/library      v1.2.0   published  https://github.com/streamingfast/library/releases/tag/v1.2.0
+		../application  streamingfast/application  v0.5.0   failed     -
+		../other        -                          -        pending    -
+	`), "  ")+"\n", out.String())
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/sfreleaser/github"
+)
+
+const releaseFailureExitHandlerID = "release-failure"
+
+type releaseFailurePolicy string
+
+const (
+	// releaseFailureKeep leaves the draft release on GitHub as is (default)
+	releaseFailureKeep releaseFailurePolicy = "keep"
+	// releaseFailureDeleteDraft deletes the draft release created by the failed release, with its uploaded assets
+	releaseFailureDeleteDraft releaseFailurePolicy = "delete-draft"
+	// releaseFailurePrompt asks whether the draft release should be deleted, it's kept in non-interactive mode
+	releaseFailurePrompt releaseFailurePolicy = "prompt"
+)
+
+func parseReleaseFailurePolicy(in string) (releaseFailurePolicy, error) {
+	switch policy := releaseFailurePolicy(strings.ToLower(in)); policy {
+	case releaseFailureKeep, releaseFailureDeleteDraft, releaseFailurePrompt:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid on failure policy %q, accepted values are 'keep', 'delete-draft' or 'prompt'", in)
+	}
+}
+
+// releaseFailure cleans up after a release that failed (including when interrupted), the
+// draft release is handled according to the policy and the on-failure hooks are executed.
+type releaseFailure struct {
+	policy   releaseFailurePolicy
+	hooks    []*releaseHook
+	client   *github.Client
+	global   *GlobalModel
+	release  *ReleaseModel
+	buildDir string
+	progress *releaseProgress
+
+	// draftCreated is set once goreleaser starts creating the GitHub release, a draft existing
+	// before that is not ours and is never deleted.
+	draftCreated bool
+}
+
+// register installs the exit handler performing the cleanup, every failure path exits through
+// [cli.Exit] (the 'OnCommandError' of the command, the interrupt handler, [cli.NoError], etc.).
+func (f *releaseFailure) register() {
+	cli.ExitHandler(releaseFailureExitHandlerID, func(code int) {
+		if code == 0 {
+			return
+		}
+
+		// A failure while cleaning up exits again, the cleanup must not run a second time
+		cli.ExitHandler(releaseFailureExitHandlerID, nil)
+
+		f.handle()
+	})
+}
+
+func (f *releaseFailure) handle() {
+	releaseURL := ""
+	if f.draftCreated {
+		releaseURL = f.cleanupDraftRelease()
+	}
+
+	if len(f.hooks) > 0 {
+		fmt.Println()
+		fmt.Printf("Executing %d on-failure hook(s)\n", len(f.hooks))
+
+		model := map[string]any{
+			"global":     f.global,
+			"release":    f.release,
+			"buildDir":   f.buildDir,
+			"releaseURL": releaseURL,
+		}
+
+		for _, hook := range f.hooks {
+			// The release already failed, a failing hook must not prevent the next ones from running
+			onFailureHook := *hook
+			onFailureHook.ContinueOnError = true
+
+			executeHook(&onFailureHook, model, hookEnvironment(model))
+		}
+	}
+}
+
+// cleanupDraftRelease applies the policy to the draft release and returns the URL of the
+// release left on GitHub, empty if there is none.
+func (f *releaseFailure) cleanupDraftRelease() (releaseURL string) {
+	state, ghRelease := releaseState(f.client, f.global, f.release.Version)
+
+	switch state {
+	case ghReleaseNotFound:
+		return ""
+
+	case ghReleaseExists:
+		// Failed after publishing (e.g. a post-publish hook), there is nothing to clean up
+		return ghRelease.HTMLURL
+	}
+
+	fmt.Println()
+	fmt.Printf("The release failed, its draft release %q is at %s\n", ghRelease.TagName, ghRelease.HTMLURL)
+
+	deleteDraft := f.policy == releaseFailureDeleteDraft
+	if f.policy == releaseFailurePrompt {
+		deleteDraft = confirm("Would you like to delete the draft release and its uploaded assets?", "on-failure=delete-draft", false)
+	}
+
+	if !deleteDraft {
+		fmt.Println("Keeping draft release, 'sfreleaser release --resume' continues the release with it")
+		return ghRelease.HTMLURL
+	}
+
+	deleteExistingRelease(f.client, f.global, ghRelease)
+	f.progress.ResetGitHubRelease()
+
+	return ""
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseReleaseFailurePolicy(t *testing.T) {
+	policy, err := parseReleaseFailurePolicy("Delete-Draft")
+	require.NoError(t, err)
+	assert.Equal(t, releaseFailureDeleteDraft, policy)
+
+	_, err = parseReleaseFailurePolicy("delete")
+	require.EqualError(t, err, `invalid on failure policy "delete", accepted values are 'keep', 'delete-draft' or 'prompt'`)
+}
+
+func Test_releaseFailure_handle_BeforeDraft(t *testing.T) {
+	activePlan = newExecutionPlan()
+	t.Cleanup(func() { activePlan = nil })
+
+	failure := &releaseFailure{
+		policy:   releaseFailureDeleteDraft,
+		hooks:    []*releaseHook{{Command: `notify {{ .global.Project }} {{ .release.Version }} url={{ .releaseURL }}`}, {Command: "cleanup", OnlyFor: &hookCondition{Language: stringOrList{"rust"}}}},
+		global:   &GlobalModel{Owner: "streamingfast", Project: "firehose", Language: LanguageGolang},
+		release:  &ReleaseModel{Version: "v1.2.0"},
+		buildDir: "build",
+	}
+
+	// No draft was created yet, the GitHub release is not looked up
+	failure.handle()
+
+	assert.Equal(t, []string{"notify firehose v1.2.0 url="}, activePlan.steps)
+}
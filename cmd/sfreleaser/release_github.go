@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/streamingfast/cli"
+	"github.com/streamingfast/sfreleaser/github"
+	"go.uber.org/zap"
 )
 
 func buildArtifacts(global *GlobalModel, build *BuildModel, githubRelease *GitHubReleaseModel) {
@@ -42,17 +48,79 @@ func buildArtifacts(global *GlobalModel, build *BuildModel, githubRelease *GitHu
 	run(goreleaseDockerCommand(global, githubRelease, "build", nil, goreleaserArguments)...)
 }
 
-func releaseGithub(global *GlobalModel, release *ReleaseModel, githubRelease *GitHubReleaseModel) {
+const (
+	targetCommitishMaxAttempts = 5
+	targetCommitishMinDelay    = 5 * time.Second
+	targetCommitishPollTimeout = 2 * time.Minute
+)
+
+func releaseGithub(client *github.Client, global *GlobalModel, release *ReleaseModel, githubRelease *GitHubReleaseModel) {
 	if devSkipGoreleaser {
 		return
 	}
 
 	renderGoreleaserFile(global, release, githubRelease)
 
-	fmt.Println()
-	run(goreleaseDockerCommand(global, githubRelease, "release", nil, []string{
+	command := goreleaseDockerCommand(global, githubRelease, "release", nil, []string{
 		"--release-notes=" + githubRelease.ReleaseNotesPath,
-	})...)
+	})
+
+	for attempt := 1; ; attempt++ {
+		fmt.Println()
+		output, info, err := maybeRun(command...)
+		if err == nil {
+			return
+		}
+
+		if !isTargetCommitishRaceError(output) || attempt >= targetCommitishMaxAttempts {
+			cli.NoError(err, "Command %q failed", info)
+		}
+
+		// The release is created against the commit we just pushed, it happens that GitHub
+		// does not know about it yet leading to a 422 Validation Failed on 'target_commitish'.
+		// Goreleaser replaces the existing draft, so it's safe to retry.
+		delay := targetCommitishMinDelay * time.Duration(1<<(attempt-1))
+
+		fmt.Println()
+		fmt.Printf("GitHub rejected the release because it does not know about the commit yet (422 'target_commitish'), retrying in %s (attempt %d/%d)\n", delay, attempt+1, targetCommitishMaxAttempts)
+		time.Sleep(delay)
+
+		waitForGitHubCommit(client, global, strings.TrimSpace(resultOf("git rev-parse HEAD")))
+	}
+}
+
+// isTargetCommitishRaceError returns true if the Goreleaser output contains the GitHub
+// error received when the release's commit is not yet visible to GitHub release service.
+func isTargetCommitishRaceError(output string) bool {
+	return strings.Contains(output, "422 Validation Failed") && strings.Contains(output, "target_commitish")
+}
+
+func waitForGitHubCommit(client *github.Client, global *GlobalModel, commit string) {
+	zlog.Debug("waiting for GitHub to know about commit", zap.String("commit", commit))
+
+	ctx, cancel := context.WithTimeout(context.Background(), targetCommitishPollTimeout)
+	defer cancel()
+
+	for {
+		exists, err := client.CommitExists(ctx, global.Owner, global.Project, commit)
+		if err == nil && exists {
+			return
+		}
+
+		if err != nil {
+			zlog.Debug("checking commit existence failed", zap.String("commit", commit), zap.Error(err))
+		}
+
+		fmt.Printf("Waiting for GitHub to report commit %s...\n", commit)
+
+		select {
+		case <-ctx.Done():
+			// We still retry, the next attempt might work anyway
+			fmt.Printf("GitHub still does not report commit %s after %s, retrying anyway\n", commit, targetCommitishPollTimeout)
+			return
+		case <-time.After(targetCommitishMinDelay):
+		}
+	}
 }
 
 func goreleaseDockerCommand(global *GlobalModel, githubRelease *GitHubReleaseModel, command string, dockerExtraArguments []string, goReleaserExtraArguments []string) []string {
@@ -61,6 +129,15 @@ func goreleaseDockerCommand(global *GlobalModel, githubRelease *GitHubReleaseMod
 		platform = "linux/arm64"
 	}
 
+	// Goreleaser needs the Git repository, which is a parent directory when the project is
+	// in a subdirectory of it (e.g. a monorepo), so the repository is mounted instead.
+	mountDirectory, workDirectory := cli.WorkingDirectory(), "/go/src/work"
+	if topLevel, err := gitTopLevelDirectory(mountDirectory); err == nil {
+		if relative, err := filepath.Rel(topLevel, mountDirectory); err == nil && relative != "." && !strings.HasPrefix(relative, "..") {
+			mountDirectory, workDirectory = topLevel, path.Join(workDirectory, filepath.ToSlash(relative))
+		}
+	}
+
 	arguments := []string{
 		"docker",
 
@@ -70,8 +147,13 @@ func goreleaseDockerCommand(global *GlobalModel, githubRelease *GitHubReleaseMod
 		"-e CGO_ENABLED=1",
 		"--env-file", githubRelease.EnvFilePath,
 		"-v /var/run/docker.sock:/var/run/docker.sock",
-		"-v", cli.WorkingDirectory() + ":/go/src/work",
-		"-w /go/src/work",
+		"-v", mountDirectory + ":/go/src/work",
+		"-w", workDirectory,
+	}
+
+	if global.TagPrefix != "" {
+		// The 'monorepo' configuration requires Goreleaser Pro, its key is forwarded from the environment
+		arguments = append(arguments, "-e GORELEASER_KEY")
 	}
 
 	if global.Language == LanguageGolang {
@@ -49,6 +49,21 @@ func Test_releaseGithub(t *testing.T) {
 			}),
 			"goreleaser/app/readme_file_and_license.golden.yaml",
 		},
+		{
+			"pre-release",
+			newReleaseGithubArgs(func(tt *testing.T, args *releaseGithubArgs) {
+				args.release.Version = "v1.0.0-rc.1"
+				args.release.Prerelease = true
+			}),
+			"goreleaser/app/prerelease.golden.yaml",
+		},
+		{
+			"tag prefix",
+			newReleaseGithubArgs(func(tt *testing.T, args *releaseGithubArgs) {
+				args.global.TagPrefix = "component/"
+			}),
+			"goreleaser/app/tag_prefix.golden.yaml",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -110,6 +125,24 @@ func newReleaseGithubArgs(customize func(*testing.T, *releaseGithubArgs)) func(t
 	}
 }
 
-func ptr[T any](v T) *T {
-	return &v
+func Test_isTargetCommitishRaceError(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"empty", "", false},
+		{
+			"target_commitish race",
+			"  ⨯ release failed after 2s  error=scm releases: failed to publish artifacts: could not release: POST https://api.github.com/repos/streamingfast/substreams-ethereum/releases: 422 Validation Failed [{Resource:Release Field:target_commitish Code:invalid Message:}]",
+			true,
+		},
+		{"other validation", "POST https://api.github.com/repos/o/r/releases: 422 Validation Failed [{Resource:Release Field:tag_name Code:already_exists Message:}]", false},
+		{"other error", "homebrew tap formula: failed to publish artifacts: 404 Not Found", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isTargetCommitishRaceError(tt.output))
+		})
+	}
 }
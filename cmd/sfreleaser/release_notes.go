@@ -1,8 +1,6 @@
 package main
 
 import (
-	"bufio"
-	"os"
 	"regexp"
 	"strings"
 
@@ -18,22 +16,15 @@ func readVersionFromChangelog(changelogFile string) string {
 		return ""
 	}
 
-	file, err := os.Open(changelogFile)
-	cli.NoError(err, "Unable to open changelog %q", changelogFile)
-	defer file.Close()
+	changelog, err := parseChangelogFile(changelogFile)
+	cli.NoError(err, "Unable to parse changelog %q", changelogFile)
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if headerRegex.MatchString(line) {
-			return extractVersionFromHeader(line)
-		}
+	if len(changelog.Releases) == 0 {
+		// We found nothing!
+		return ""
 	}
 
-	cli.NoError(scanner.Err(), "Unable to scan lines from changelog")
-
-	// We found nothing!
-	return ""
+	return changelog.Releases[0].Version
 }
 
 var versionRegex = regexp.MustCompile(`v?([0-9]+\.[0-9]+\.[0-9]+[a-zA-Z0-9\-_\.]*)`)
@@ -68,37 +59,22 @@ func extractVersionFromHeader(header string) string {
 	return ""
 }
 
-func readReleaseNotes(changelogFile string) string {
+func isUnreleasedHeader(line string) bool {
+	groups := changelogReleaseHeaderRegex.FindStringSubmatch(line)
+	return groups != nil && isUnreleasedHeaderName(groups[1]+groups[2])
+}
+
+// readReleaseNotes returns the changelog notes of `version`, see [Changelog.ReleaseNotes].
+func readReleaseNotes(changelogFile string, version string) string {
 	if !cli.FileExists(changelogFile) {
 		return ""
 	}
 
-	file, err := os.Open(changelogFile)
-	cli.NoError(err, "Unable to open changelog %q", changelogFile)
-	defer file.Close()
-
-	foundFirstHeader := false
-	var releaseNotes []string
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if !foundFirstHeader && headerRegex.MatchString(line) {
-			foundFirstHeader = true
-			continue
-		}
-
-		if foundFirstHeader {
-			if headerRegex.MatchString(line) {
-				break
-			}
-
-			releaseNotes = append(releaseNotes, line)
-		}
-	}
+	changelog, err := parseChangelogFile(changelogFile)
+	cli.NoError(err, "Unable to parse changelog %q", changelogFile)
 
-	zlog.Debug("computed changelog lines", zap.Strings("release_lines", releaseNotes))
+	releaseNotes := changelog.ReleaseNotes(version)
+	zlog.Debug("computed changelog release notes", zap.String("version", version), zap.String("release_notes", releaseNotes))
 
-	cli.NoError(scanner.Err(), "Unable to scan lines from changelog")
-	return trimBlankLines(strings.Join(releaseNotes, "\n"))
+	return releaseNotes
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/cli"
+	"go.uber.org/zap"
+)
+
+type releaseNotesSource string
+
+const (
+	// releaseNotesSourceChangelog uses the changelog section of the version (default), see [Changelog.ReleaseNotes]
+	releaseNotesSourceChangelog releaseNotesSource = "changelog"
+	// releaseNotesSourceCommits generates the notes from the conventional commits since the latest tag
+	releaseNotesSourceCommits releaseNotesSource = "commits"
+	// releaseNotesSourceHybrid uses the changelog notes with a "Commits" appendix
+	releaseNotesSourceHybrid releaseNotesSource = "hybrid"
+)
+
+func parseReleaseNotesSource(in string) (releaseNotesSource, error) {
+	switch source := releaseNotesSource(strings.ToLower(in)); source {
+	case releaseNotesSourceChangelog, releaseNotesSourceCommits, releaseNotesSourceHybrid:
+		return source, nil
+	default:
+		return "", fmt.Errorf("invalid release notes source %q, accepted values are 'changelog', 'commits' or 'hybrid'", in)
+	}
+}
+
+// resolveReleaseNotes computes the release notes content of `version` according to `source`.
+func resolveReleaseNotes(source releaseNotesSource, changelogPath string, version string, global *GlobalModel) string {
+	if source == releaseNotesSourceChangelog {
+		return readReleaseNotes(changelogPath, version)
+	}
+
+	remote := resolveGitRemote(global)
+	commits, err := readCommitsSinceTag(remote, latestTag(remote, global.TagPrefix))
+	cli.NoError(err, "Unable to read commits since latest tag")
+	repositoryURL := fmt.Sprintf("https://github.com/%s/%s", global.Owner, global.Project)
+
+	if source == releaseNotesSourceCommits {
+		return renderCommitsReleaseNotes(commits, repositoryURL)
+	}
+
+	releaseNotes := readReleaseNotes(changelogPath, version)
+	appendix := renderCommitsReleaseNotes(commits, repositoryURL)
+	if appendix == "" {
+		return releaseNotes
+	}
+
+	if releaseNotes == "" {
+		return "## Commits\n\n" + appendix
+	}
+
+	return releaseNotes + "\n\n## Commits\n\n" + appendix
+}
+
+type conventionalCommit struct {
+	Hash        string
+	Type        string
+	Scope       string
+	Subject     string
+	Breaking    bool
+	PullRequest int
+}
+
+const (
+	gitLogFieldSeparator  = "\x1f"
+	gitLogRecordSeparator = "\x1e"
+)
+
+// readCommitsSinceTag returns the commits reachable from HEAD but not from `tag` (all of them if
+// `tag` is empty), following only the first parent so that merged pull requests appear once.
+func readCommitsSinceTag(remote string, tag string) ([]*conventionalCommit, error) {
+	revisionRange := "HEAD"
+	if tag != "" {
+		// The tag is resolved from the remote, it might not exist locally yet
+		if _, _, err := maybeResultOf("git rev-parse --verify --quiet", tag+"^{commit}"); err != nil {
+			zlog.Debug("latest tag not found locally, fetching it", zap.String("tag", tag), zap.String("remote", remote))
+			if output, _, err := maybeResultOf("git fetch --no-tags", remote, "tag", tag); err != nil {
+				return nil, fmt.Errorf("fetch tag %q from remote %q: %s", tag, remote, strings.TrimSpace(output))
+			}
+		}
+
+		revisionRange = tag + "..HEAD"
+	}
+
+	output, info, err := maybeResultOf("git log --first-parent", "--format='%H%x1f%s%x1f%b%x1e'", revisionRange)
+	if err != nil {
+		return nil, fmt.Errorf("command %q failed: %s", info, strings.TrimSpace(output))
+	}
+
+	commits := parseGitLogOutput(output)
+
+	zlog.Debug("read commits since latest tag", zap.String("range", revisionRange), zap.Int("count", len(commits)))
+	return commits, nil
+}
+
+var (
+	conventionalCommitRegex = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	mergePullRequestRegex   = regexp.MustCompile(`^Merge pull request #([0-9]+) from \S+`)
+	squashPullRequestRegex  = regexp.MustCompile(`\s*\(#([0-9]+)\)$`)
+	breakingChangeRegex     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+)
+
+// parseGitLogOutput parses the output of 'git log' using the format '%H%x1f%s%x1f%b%x1e'. Merge
+// commits of pull requests use the first line of their body (the pull request title) as subject,
+// other merge commits are skipped.
+func parseGitLogOutput(output string) (commits []*conventionalCommit) {
+	for _, record := range strings.Split(output, gitLogRecordSeparator) {
+		fields := strings.SplitN(strings.TrimSpace(record), gitLogFieldSeparator, 3)
+		if len(fields) < 2 {
+			continue
+		}
+
+		commit := &conventionalCommit{Hash: fields[0]}
+		subject := strings.TrimSpace(fields[1])
+
+		body := ""
+		if len(fields) == 3 {
+			body = strings.TrimSpace(fields[2])
+		}
+
+		if groups := mergePullRequestRegex.FindStringSubmatch(subject); groups != nil {
+			commit.PullRequest, _ = strconv.Atoi(groups[1])
+			subject, _, _ = strings.Cut(body, "\n")
+			subject = strings.TrimSpace(subject)
+		} else if strings.HasPrefix(subject, "Merge ") {
+			continue
+		}
+
+		if groups := squashPullRequestRegex.FindStringSubmatch(subject); groups != nil {
+			commit.PullRequest, _ = strconv.Atoi(groups[1])
+			subject = strings.TrimSuffix(subject, groups[0])
+		}
+
+		if groups := conventionalCommitRegex.FindStringSubmatch(subject); groups != nil {
+			commit.Type = strings.ToLower(groups[1])
+			commit.Scope = groups[2]
+			commit.Breaking = groups[3] == "!"
+			subject = groups[4]
+		}
+
+		commit.Subject = subject
+		commit.Breaking = commit.Breaking || breakingChangeRegex.MatchString(body)
+
+		commits = append(commits, commit)
+	}
+
+	return
+}
+
+var commitGroups = []struct {
+	title   string
+	matches func(commit *conventionalCommit) bool
+}{
+	{"Breaking Changes", func(commit *conventionalCommit) bool { return commit.Breaking }},
+	{"Features", func(commit *conventionalCommit) bool { return commit.Type == "feat" }},
+	{"Fixes", func(commit *conventionalCommit) bool { return commit.Type == "fix" }},
+	{"Performance", func(commit *conventionalCommit) bool { return commit.Type == "perf" }},
+	{"Other Changes", func(commit *conventionalCommit) bool { return true }},
+}
+
+// renderCommitsReleaseNotes groups the commits by conventional commit type, each group being
+// rendered under a '### ' header. A commit appears in the first group it matches only.
+func renderCommitsReleaseNotes(commits []*conventionalCommit, repositoryURL string) string {
+	grouped := make([][]*conventionalCommit, len(commitGroups))
+
+	for _, commit := range commits {
+		for i, group := range commitGroups {
+			if group.matches(commit) {
+				grouped[i] = append(grouped[i], commit)
+				break
+			}
+		}
+	}
+
+	var sections []string
+	for i, group := range commitGroups {
+		if len(grouped[i]) == 0 {
+			continue
+		}
+
+		lines := []string{"### " + group.title, ""}
+		for _, commit := range grouped[i] {
+			lines = append(lines, renderCommitEntry(commit, repositoryURL))
+		}
+
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func renderCommitEntry(commit *conventionalCommit, repositoryURL string) string {
+	entry := "- "
+	if commit.Scope != "" {
+		entry += "**" + commit.Scope + "**: "
+	}
+
+	entry += commit.Subject
+
+	if commit.PullRequest != 0 {
+		entry += fmt.Sprintf(" ([#%d](%s/pull/%d))", commit.PullRequest, repositoryURL, commit.PullRequest)
+	} else if len(commit.Hash) >= 7 {
+		entry += fmt.Sprintf(" ([%s](%s/commit/%s))", commit.Hash[:7], repositoryURL, commit.Hash)
+	}
+
+	return entry
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseGitLogOutput(t *testing.T) {
+	record := func(hash, subject, body string) string {
+		return strings.Join([]string{hash, subject, body}, gitLogFieldSeparator) + gitLogRecordSeparator + "\n"
+	}
+
+	output := record("1111111aaaa", "feat(cli): added --json flag (#12)", "") +
+		record("2222222bbbb", "Merge pull request #13 from owner/fix-crash", "fix: crash on empty changelog\n\nDetails") +
+		record("3333333cccc", "Merge branch 'develop'", "") +
+		record("4444444dddd", "refactor!: dropped legacy config", "") +
+		record("5555555eeee", "perf: faster parsing", "BREAKING CHANGE: cache format changed") +
+		record("6666666ffff", "Updated README", "")
+
+	assert.Equal(t, []*conventionalCommit{
+		{Hash: "1111111aaaa", Type: "feat", Scope: "cli", Subject: "added --json flag", PullRequest: 12},
+		{Hash: "2222222bbbb", Type: "fix", Subject: "crash on empty changelog", PullRequest: 13},
+		{Hash: "4444444dddd", Type: "refactor", Subject: "dropped legacy config", Breaking: true},
+		{Hash: "5555555eeee", Type: "perf", Subject: "faster parsing", Breaking: true},
+		{Hash: "6666666ffff", Subject: "Updated README"},
+	}, parseGitLogOutput(output))
+
+	assert.Empty(t, parseGitLogOutput(""))
+}
+
+func Test_renderCommitsReleaseNotes(t *testing.T) {
+	commits := []*conventionalCommit{
+		{Hash: "1111111aaaa", Type: "feat", Scope: "cli", Subject: "added --json flag", PullRequest: 12},
+		{Hash: "2222222bbbb", Type: "fix", Subject: "crash on empty changelog", PullRequest: 13},
+		{Hash: "4444444dddd", Type: "refactor", Subject: "dropped legacy config", Breaking: true},
+		{Hash: "5555555eeee", Type: "perf", Subject: "faster parsing"},
+		{Hash: "6666666ffff", Subject: "Updated README"},
+	}
+
+	want := dedent(`
+		### Breaking Changes
+
+		- dropped legacy config ([4444444](https://github.com/owner/project/commit/4444444dddd))
+
+		### Features
+
+		- **cli**: added --json flag ([#12](https://github.com/owner/project/pull/12))
+
+		### Fixes
+
+		- crash on empty changelog ([#13](https://github.com/owner/project/pull/13))
+
+		### Performance
+
+		- faster parsing ([5555555](https://github.com/owner/project/commit/5555555eeee))
+
+		### Other Changes
+
+		- Updated README ([6666666](https://github.com/owner/project/commit/6666666ffff))
+	`)
+
+	assert.Equal(t, want, renderCommitsReleaseNotes(commits, "https://github.com/owner/project"))
+	assert.Equal(t, "", renderCommitsReleaseNotes(nil, "https://github.com/owner/project"))
+}
+
+func Test_parseReleaseNotesSource(t *testing.T) {
+	source, err := parseReleaseNotesSource("Hybrid")
+	require.NoError(t, err)
+	assert.Equal(t, releaseNotesSourceHybrid, source)
+
+	_, err = parseReleaseNotesSource("git")
+	require.EqualError(t, err, `invalid release notes source "git", accepted values are 'changelog', 'commits' or 'hybrid'`)
+}
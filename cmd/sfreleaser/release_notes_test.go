@@ -97,7 +97,7 @@ func Test_readReleaseNotes(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := readReleaseNotes(tt.filepath)
+			got := readReleaseNotes(tt.filepath, "")
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -167,7 +167,7 @@ This is empty.`,
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpFile := createTempChangelog(t, tt.content)
-			got := readReleaseNotes(tmpFile)
+			got := readReleaseNotes(tmpFile, "")
 			assert.Equal(t, tt.want, got)
 		})
 	}
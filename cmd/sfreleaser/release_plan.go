@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// activePlan is non-nil when running in dry-run mode, in which case [run] and [runSilent]
+// (as well as any other mutating operations) records what they would have done in the plan
+// instead of actually executing it.
+//
+// Read-only commands executed through [resultOf] and [maybeResultOf] are still executed
+// as they are required to compute the plan itself (latest tag, git sync state, etc.).
+var activePlan *executionPlan
+
+type executionPlan struct {
+	// details are key/value pairs printed before the steps of the plan, the ordering
+	// is preserved.
+	details [][2]string
+	steps   []string
+}
+
+func newExecutionPlan() *executionPlan {
+	return &executionPlan{}
+}
+
+func isDryRun() bool {
+	return activePlan != nil
+}
+
+// Detail records a resolved value that is printed as part of the plan's header.
+func (p *executionPlan) Detail(key string, format string, args ...any) {
+	p.details = append(p.details, [2]string{key, fmt.Sprintf(format, args...)})
+}
+
+// Command records a command that would have been executed.
+func (p *executionPlan) Command(info *commandInfo) {
+	p.Step("%s", info)
+}
+
+// Step records a step that would have been performed, use this for operations that
+// are not shell commands.
+func (p *executionPlan) Step(format string, args ...any) {
+	step := fmt.Sprintf(format, args...)
+	zlog.Debug("recording dry-run step", zap.String("step", step))
+
+	fmt.Printf("[dry-run] Would execute: %s\n", step)
+	p.steps = append(p.steps, step)
+}
+
+func (p *executionPlan) String() string {
+	var out strings.Builder
+	out.WriteString("Release plan (dry-run, nothing was pushed, tagged or uploaded)\n")
+
+	if len(p.details) > 0 {
+		out.WriteString("\n")
+
+		width := 0
+		for _, detail := range p.details {
+			width = max(width, len(detail[0]))
+		}
+
+		for _, detail := range p.details {
+			fmt.Fprintf(&out, "  %-*s  %s\n", width+1, detail[0]+":", detail[1])
+		}
+	}
+
+	out.WriteString("\n")
+	if len(p.steps) == 0 {
+		out.WriteString("  No commands would be executed\n")
+	}
+
+	for i, step := range p.steps {
+		fmt.Fprintf(&out, "  %2d. %s\n", i+1, step)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_executionPlan_String(t *testing.T) {
+	plan := newExecutionPlan()
+	plan.Detail("Version", "%s", "v1.0.0")
+	plan.Detail("Git remote", "%s", "origin")
+	plan.Command(newCommandInfo("git tag", "v1.0.0"))
+	plan.Step("Upload asset %q", "build/file.spkg")
+
+	assert.Equal(t, `Release plan (dry-run, nothing was pushed, tagged or uploaded)
+
+  Version:     v1.0.0
+  Git remote:  origin
+
+   1. git tag v1.0.0
+   2. Upload asset "build/file.spkg"
+`, plan.String())
+
+	assert.Equal(t, `Release plan (dry-run, nothing was pushed, tagged or uploaded)
+
+  No commands would be executed
+`, newExecutionPlan().String())
+}
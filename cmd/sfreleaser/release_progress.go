@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/cli"
+	"go.uber.org/zap"
+)
+
+type releaseStep string
+
+const (
+	releaseStepGitSync           releaseStep = "git-sync"
+	releaseStepChangelogPromote  releaseStep = "changelog-promote"
+	releaseStepReleaseNotes      releaseStep = "release-notes"
+	releaseStepPreBuildHooks     releaseStep = "pre-build-hooks"
+	releaseStepSubstreamsPackage releaseStep = "substreams-package"
+	releaseStepTag               releaseStep = "tag"
+	releaseStepGoreleaser        releaseStep = "goreleaser-release"
+	releaseStepSubstreamsPublish releaseStep = "substreams-publish"
+	releaseStepPublish           releaseStep = "publish"
+	releaseStepPostReleaseHooks  releaseStep = "post-release-hooks"
+	releaseStepPostPublishHooks  releaseStep = "post-publish-hooks"
+)
+
+func releaseStepUploadAsset(asset string) releaseStep {
+	return releaseStep("upload-asset:" + asset)
+}
+
+func releaseStepCargoPublish(crate string) releaseStep {
+	return releaseStep("cargo-publish:" + crate)
+}
+
+// releaseProgress tracks the steps of a release that completed successfully so far. It's
+// persisted to disk after each completed step so that a failed release can be resumed
+// through 'sfreleaser release --resume', skipping already completed steps and retrying
+// from the failed one with the same version and release notes.
+type releaseProgress struct {
+	// path is the file where the progress is persisted, it's not serialized
+	path string
+
+	Version   string                  `json:"version"`
+	StartedAt time.Time               `json:"started_at"`
+	Steps     []*completedReleaseStep `json:"steps"`
+}
+
+type completedReleaseStep struct {
+	Name        releaseStep `json:"name"`
+	CompletedAt time.Time   `json:"completed_at"`
+}
+
+func newReleaseProgress(path string, version string) *releaseProgress {
+	return &releaseProgress{
+		path:      path,
+		Version:   version,
+		StartedAt: time.Now(),
+	}
+}
+
+func mustLoadReleaseProgress(path string) *releaseProgress {
+	if !cli.FileExists(path) {
+		cli.Quit("%s", dedent(`
+			No release progress found at %q, there is nothing to resume.
+
+			The progress file is written as the release advances, start a new release without
+			the '--resume' flag.
+		`, path))
+	}
+
+	content, err := os.ReadFile(path)
+	cli.NoError(err, "Unable to read release progress file %q", path)
+
+	progress := &releaseProgress{}
+	cli.NoError(json.Unmarshal(content, progress), "Unable to decode release progress file %q", path)
+
+	progress.path = path
+	return progress
+}
+
+func (p *releaseProgress) IsCompleted(step releaseStep) bool {
+	return slices.ContainsFunc(p.Steps, func(completed *completedReleaseStep) bool {
+		return completed.Name == step
+	})
+}
+
+// Run executes `fn` if `step` is not completed yet and marks the step as completed once `fn`
+// returns. When the step is already completed, `fn` is not executed.
+func (p *releaseProgress) Run(step releaseStep, fn func()) {
+	if p.IsCompleted(step) {
+		fmt.Printf("Skipping step %q, already completed in a previous run\n", step)
+		emitEvent(&outputEvent{Type: eventStepSkipped, Step: string(step)})
+		return
+	}
+
+	trackStep(string(step), fn)
+	p.Complete(step)
+}
+
+func (p *releaseProgress) Complete(step releaseStep) {
+	if p.IsCompleted(step) {
+		return
+	}
+
+	p.Steps = append(p.Steps, &completedReleaseStep{Name: step, CompletedAt: time.Now()})
+	p.save()
+}
+
+// Reset marks the step as not completed anymore, used when the effect of a step is undone,
+// like the temporary tag that is deleted when the process exits.
+func (p *releaseProgress) Reset(step releaseStep) {
+	p.Steps = slices.DeleteFunc(p.Steps, func(completed *completedReleaseStep) bool {
+		return completed.Name == step
+	})
+	p.save()
+}
+
+// ResetGitHubRelease marks the steps creating and filling the GitHub release as not completed
+// anymore, used when the draft release is deleted so that resuming creates it again.
+func (p *releaseProgress) ResetGitHubRelease() {
+	p.Steps = slices.DeleteFunc(p.Steps, func(completed *completedReleaseStep) bool {
+		return completed.Name == releaseStepGoreleaser || completed.Name == releaseStepPostReleaseHooks || strings.HasPrefix(string(completed.Name), string(releaseStepUploadAsset("")))
+	})
+	p.save()
+}
+
+// Finish removes the persisted progress once the release is published, a later '--resume'
+// then reports that there is nothing to resume instead of skipping every step.
+func (p *releaseProgress) Finish() {
+	if isDryRun() {
+		return
+	}
+
+	zlog.Debug("release completed, removing release progress", zap.String("path", p.path))
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		cli.NoError(err, "Unable to remove release progress file %q", p.path)
+	}
+}
+
+func (p *releaseProgress) save() {
+	if isDryRun() {
+		// Dry-run must never make a real run believe some steps were performed
+		return
+	}
+
+	content, err := json.MarshalIndent(p, "", "  ")
+	cli.NoError(err, "Unable to encode release progress")
+
+	zlog.Debug("saving release progress", zap.String("path", p.path), zap.Int("completed_steps", len(p.Steps)))
+	cli.WriteFile(p.path, "%s", string(content))
+}
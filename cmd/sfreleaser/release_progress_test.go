@@ -0,0 +1,74 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_releaseProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".release_state.json")
+
+	progress := newReleaseProgress(path, "v1.0.0")
+
+	var executed []releaseStep
+	step := func(step releaseStep) {
+		progress.Run(step, func() { executed = append(executed, step) })
+	}
+
+	step(releaseStepGitSync)
+	step(releaseStepTag)
+	step(releaseStepUploadAsset("file.spkg"))
+	progress.Reset(releaseStepTag)
+
+	loaded := mustLoadReleaseProgress(path)
+	require.Equal(t, "v1.0.0", loaded.Version)
+
+	progress = loaded
+	step(releaseStepGitSync)
+	step(releaseStepTag)
+	step(releaseStepUploadAsset("file.spkg"))
+	step(releaseStepPublish)
+
+	assert.Equal(t, []releaseStep{
+		releaseStepGitSync,
+		releaseStepTag,
+		releaseStepUploadAsset("file.spkg"),
+		releaseStepTag,
+		releaseStepPublish,
+	}, executed)
+
+	assert.True(t, mustLoadReleaseProgress(path).IsCompleted(releaseStepPublish))
+}
+
+func Test_releaseProgress_Finish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".release_state.json")
+
+	progress := newReleaseProgress(path, "v1.0.0")
+	progress.Complete(releaseStepPublish)
+	require.FileExists(t, path)
+
+	progress.Finish()
+	assert.NoFileExists(t, path)
+
+	// Already removed, nothing to do
+	progress.Finish()
+}
+
+func Test_releaseProgress_ResetGitHubRelease(t *testing.T) {
+	progress := newReleaseProgress(filepath.Join(t.TempDir(), ".release_state.json"), "v1.0.0")
+	for _, step := range []releaseStep{releaseStepGitSync, releaseStepTag, releaseStepGoreleaser, releaseStepUploadAsset("file.spkg"), releaseStepPostReleaseHooks} {
+		progress.Complete(step)
+	}
+
+	progress.ResetGitHubRelease()
+
+	var steps []releaseStep
+	for _, step := range mustLoadReleaseProgress(progress.path).Steps {
+		steps = append(steps, step.Name)
+	}
+
+	assert.Equal(t, []releaseStep{releaseStepGitSync, releaseStepTag}, steps)
+}
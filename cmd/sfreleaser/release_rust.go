@@ -32,7 +32,7 @@ func printRustCratesNotPublishedMessage(rust *RustReleaseModel) {
 	`))
 }
 
-func releaseRustPublishCrates(rust *RustReleaseModel) {
+func releaseRustPublishCrates(rust *RustReleaseModel, progress *releaseProgress) {
 	cli.Ensure(rust != nil, "Rust model should have been populated by now but it's currently nil")
 
 	if devSkipRustCargoPublish {
@@ -40,7 +40,10 @@ func releaseRustPublishCrates(rust *RustReleaseModel) {
 	}
 
 	for _, crate := range rust.Crates {
-		run(publishRustCrateArgs(crate, rust.CargoPublishArgs)...)
+		// Each crate is tracked individually since a crate once published cannot be published again
+		progress.Run(releaseStepCargoPublish(crate), func() {
+			run(publishRustCrateArgs(crate, rust.CargoPublishArgs)...)
+		})
 	}
 }
 
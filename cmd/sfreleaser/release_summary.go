@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/sfreleaser/github"
+)
+
+// releaseSummary is the machine-readable outcome of a release, it's written to
+// 'build/.release_summary.json' once the release completes and printed in non-interactive mode.
+type releaseSummary struct {
+	Version    string                 `json:"version"`
+	Repository string                 `json:"repository"`
+	URL        string                 `json:"url"`
+	Published  bool                   `json:"published"`
+	Prerelease bool                   `json:"prerelease"`
+	Assets     []*releaseSummaryAsset `json:"assets"`
+}
+
+type releaseSummaryAsset struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	URL  string `json:"url"`
+}
+
+func newReleaseSummary(global *GlobalModel, ghRelease *github.Release) *releaseSummary {
+	summary := &releaseSummary{
+		Version:    ghRelease.TagName,
+		Repository: fmt.Sprintf("%s/%s", global.Owner, global.Project),
+		URL:        ghRelease.HTMLURL,
+		Published:  !ghRelease.Draft,
+		Prerelease: ghRelease.Prerelease,
+		Assets:     []*releaseSummaryAsset{},
+	}
+
+	for _, asset := range ghRelease.Assets {
+		summary.Assets = append(summary.Assets, &releaseSummaryAsset{Name: asset.Name, Size: asset.Size, URL: asset.BrowserDownloadURL})
+	}
+
+	return summary
+}
+
+// writeReleaseSummary fetches the final state of the release from GitHub and writes its summary
+// to `summaryPath`, it's also printed to standard output when `print` is true.
+func writeReleaseSummary(client *github.Client, global *GlobalModel, version string, summaryPath string, print bool) {
+	ghRelease, err := client.FindRelease(context.Background(), global.Owner, global.Project, global.Tag(version))
+	cli.NoError(err, "Unable to retrieve release %q", version)
+
+	summary := newReleaseSummary(global, ghRelease)
+	emitEvent(&outputEvent{Type: eventReleaseURL, Version: version, URL: summary.URL, Published: ptr(summary.Published)})
+
+	content, err := json.MarshalIndent(summary, "", "  ")
+	cli.NoError(err, "Unable to marshal release summary")
+
+	cli.WriteFile(summaryPath, "%s\n", content)
+
+	if print {
+		fmt.Println()
+		fmt.Println("Release summary:")
+		fmt.Println(string(content))
+	}
+}
+
+// releaseHookModel returns the template model of the post-release and post-publish hooks, it's
+// the model of pre-build hooks augmented with the current state of the GitHub release. The
+// release does not exist in dry-run mode, `published` is then used as its published state.
+func releaseHookModel(client *github.Client, global *GlobalModel, release *ReleaseModel, buildDir string, published bool) map[string]any {
+	model := map[string]any{
+		"global":   global,
+		"release":  release,
+		"buildDir": buildDir,
+	}
+
+	if isDryRun() {
+		model["releaseURL"] = releaseURL(client, global, release.Version)
+		model["assets"] = []*releaseSummaryAsset{}
+		model["published"] = published
+
+		return model
+	}
+
+	// A single lookup, finding the release lists the releases of the repository
+	ghRelease, err := client.FindRelease(context.Background(), global.Owner, global.Project, global.Tag(release.Version))
+	cli.NoError(err, "Unable to retrieve release %q", release.Version)
+
+	summary := newReleaseSummary(global, ghRelease)
+	model["releaseURL"] = summary.URL
+	model["assets"] = summary.Assets
+	model["published"] = summary.Published
+
+	return model
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/streamingfast/sfreleaser/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newReleaseSummary(t *testing.T) {
+	global := &GlobalModel{Owner: "streamingfast", Project: "sfreleaser"}
+
+	tests := []struct {
+		name    string
+		release *github.Release
+		want    *releaseSummary
+	}{
+		{
+			"draft without assets",
+			&github.Release{TagName: "v1.0.0", Draft: true, HTMLURL: "https://github.com/streamingfast/sfreleaser/releases/tag/untagged-1"},
+			&releaseSummary{
+				Version:    "v1.0.0",
+				Repository: "streamingfast/sfreleaser",
+				URL:        "https://github.com/streamingfast/sfreleaser/releases/tag/untagged-1",
+				Assets:     []*releaseSummaryAsset{},
+			},
+		},
+		{
+			"published pre-release with assets",
+			&github.Release{TagName: "v1.0.0-rc.1", Prerelease: true, HTMLURL: "https://github.com/streamingfast/sfreleaser/releases/tag/v1.0.0-rc.1", Assets: []*github.Asset{
+				{ID: 1, Name: "sfreleaser_linux_x86_64.tar.gz", Size: 1024, BrowserDownloadURL: "https://github.com/streamingfast/sfreleaser/releases/download/v1.0.0-rc.1/sfreleaser_linux_x86_64.tar.gz"},
+			}},
+			&releaseSummary{
+				Version:    "v1.0.0-rc.1",
+				Repository: "streamingfast/sfreleaser",
+				URL:        "https://github.com/streamingfast/sfreleaser/releases/tag/v1.0.0-rc.1",
+				Published:  true,
+				Prerelease: true,
+				Assets: []*releaseSummaryAsset{
+					{Name: "sfreleaser_linux_x86_64.tar.gz", Size: 1024, URL: "https://github.com/streamingfast/sfreleaser/releases/download/v1.0.0-rc.1/sfreleaser_linux_x86_64.tar.gz"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newReleaseSummary(global, tt.release))
+		})
+	}
+}
+
+func Test_isCIEnvironment(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"true", true},
+		{"1", true},
+		{"false", false},
+		{"yes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("CI", tt.value)
+			assert.Equal(t, tt.want, isCIEnvironment())
+		})
+	}
+}
+
+func Test_releaseHookModel_DryRun(t *testing.T) {
+	activePlan = newExecutionPlan()
+	t.Cleanup(func() { activePlan = nil })
+
+	global := &GlobalModel{Owner: "streamingfast", Project: "firehose", TagPrefix: "firehose-ethereum/"}
+	model := releaseHookModel(nil, global, &ReleaseModel{Version: "v1.2.0"}, "build", true)
+
+	executeHooksWithModel(commandHooks(
+		`notify {{ .global.Project }} {{ .release.Version }} {{ .releaseURL }} published={{ .published }} assets={{ len .assets }}`,
+	), model)
+
+	assert.Equal(t, []string{
+		"notify firehose v1.2.0 https://github.com/streamingfast/firehose/releases/tag/firehose-ethereum/v1.2.0 published=true assets=0",
+	}, activePlan.steps)
+}
@@ -8,9 +8,9 @@ import (
 	"github.com/streamingfast/cli"
 )
 
-func findAllRustCrates() (crates []string) {
+func findAllRustCrates(root string) (crates []string) {
 	cargoManifests := map[string]bool{}
-	cli.NoError(filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+	cli.NoError(filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -31,6 +31,9 @@ var sfreleaserRustYamlTmpl []byte
 //go:embed templates/sfreleaser-substreams.yaml.gotmpl
 var sfreleaserSubstreamsYamlTmpl []byte
 
+//go:embed templates/github-workflow-release.yml.gotmpl
+var githubReleaseWorkflowTmpl []byte
+
 func getInstallTemplateModel(global *GlobalModel, noBinaries bool) map[string]any {
 	return map[string]any{
 		"global":     global,
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/streamingfast/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_renderGitHubReleaseWorkflow_TagPrefix(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".github", "workflows", "release.yml")
+	model := getInstallTemplateModel(&GlobalModel{Language: LanguageGolang, Variant: VariantApplication, TagPrefix: "firehose-ethereum/"}, false)
+
+	require.Equal(t, file, renderTemplate(file, false, githubReleaseWorkflowTmpl, model))
+
+	content := cli.ReadFile(file)
+	assert.Contains(t, content, `- "firehose-ethereum/v*"`)
+	assert.NotContains(t, content, `- "v*"`)
+}
+
+func Test_renderGitHubReleaseWorkflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		language    Language
+		variant     Variant
+		contains    []string
+		notContains []string
+	}{
+		{
+			"golang application",
+			LanguageGolang, VariantApplication,
+			[]string{"Homebrew tap"},
+			[]string{"substreams_linux_x86_64", "CARGO_REGISTRY_TOKEN", "rust-toolchain"},
+		},
+		{
+			"golang library",
+			LanguageGolang, VariantLibrary,
+			nil,
+			[]string{"Homebrew tap", "substreams_linux_x86_64", "CARGO_REGISTRY_TOKEN", "rust-toolchain"},
+		},
+		{
+			"rust library",
+			LanguageRust, VariantLibrary,
+			[]string{"rust-toolchain", "CARGO_REGISTRY_TOKEN: ${{ secrets.CARGO_REGISTRY_TOKEN }}"},
+			[]string{"Homebrew tap", "substreams_linux_x86_64"},
+		},
+		{
+			"rust substreams",
+			LanguageRust, VariantSubstreams,
+			[]string{"rust-toolchain", "substreams_linux_x86_64"},
+			[]string{"Homebrew tap", "CARGO_REGISTRY_TOKEN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), ".github", "workflows", "release.yml")
+			model := getInstallTemplateModel(&GlobalModel{Language: tt.language, Variant: tt.variant}, false)
+
+			require.Equal(t, file, renderTemplate(file, false, githubReleaseWorkflowTmpl, model))
+
+			content := cli.ReadFile(file)
+			assert.Contains(t, content, `sfreleaser release --non-interactive --publish-now=${{ !inputs.draft }} "${{ inputs.version || github.ref_name }}"`)
+			assert.Contains(t, content, "GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}")
+
+			for _, expected := range tt.contains {
+				assert.Contains(t, content, expected)
+			}
+
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, content, unexpected)
+			}
+
+			assert.Contains(t, content, `- "v*"`)
+
+			var workflow map[string]any
+			require.NoError(t, yaml.Unmarshal([]byte(content), &workflow))
+			assert.Contains(t, workflow, "jobs")
+		})
+	}
+}
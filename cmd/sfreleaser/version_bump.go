@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	versioning "github.com/hashicorp/go-version"
+)
+
+type versionBump string
+
+const (
+	versionBumpMajor      versionBump = "major"
+	versionBumpMinor      versionBump = "minor"
+	versionBumpPatch      versionBump = "patch"
+	versionBumpPrerelease versionBump = "pre-release"
+	// versionBumpFinal is the final version of a pre-release, like 'v1.3.0' for 'v1.3.0-rc.2'
+	versionBumpFinal versionBump = "final"
+)
+
+// bumpRank orders bumps by significance, pre-release is not part of it as it's never recommended
+var bumpRank = map[versionBump]int{versionBumpPatch: 0, versionBumpMinor: 1, versionBumpMajor: 2}
+
+type bumpRecommendation struct {
+	Bump   versionBump
+	Reason string
+}
+
+// recommendVersionBump analyzes the changes since the latest release to recommend a semantic version
+// bump. The 'Unreleased' changelog section (if any) is looked at first: a 'Breaking Changes' or
+// 'Removed' sub-section implies a major bump, an 'Added' one a minor bump. Then the conventional
+// commits are looked at: a breaking commit implies a major bump, a 'feat' one a minor bump. The most
+// significant bump wins, patch being the default.
+func recommendVersionBump(unreleased *ChangelogRelease, commits []*conventionalCommit) *bumpRecommendation {
+	recommendation := &bumpRecommendation{Bump: versionBumpPatch, Reason: "no breaking changes nor new features found"}
+
+	consider := func(bump versionBump, reason string) {
+		if bumpRank[bump] > bumpRank[recommendation.Bump] {
+			recommendation.Bump = bump
+			recommendation.Reason = reason
+		}
+	}
+
+	if unreleased != nil {
+		for _, section := range unreleased.Sections {
+			switch strings.ToLower(section.Name) {
+			case "breaking changes", "breaking", "removed":
+				consider(versionBumpMajor, fmt.Sprintf("changelog 'Unreleased' has a %q section", section.Name))
+			case "added":
+				consider(versionBumpMinor, fmt.Sprintf("changelog 'Unreleased' has an %q section", section.Name))
+			}
+		}
+	}
+
+	var breaking, features []*conventionalCommit
+	for _, commit := range commits {
+		if commit.Breaking {
+			breaking = append(breaking, commit)
+		} else if commit.Type == "feat" {
+			features = append(features, commit)
+		}
+	}
+
+	if len(breaking) > 0 {
+		consider(versionBumpMajor, fmt.Sprintf("%d breaking commit(s) since latest tag, like %q", len(breaking), breaking[0].Subject))
+	}
+
+	if len(features) > 0 {
+		consider(versionBumpMinor, fmt.Sprintf("%d 'feat' commit(s) since latest tag, like %q", len(features), features[0].Subject))
+	}
+
+	return recommendation
+}
+
+// isPrereleaseVersion returns true if `version` has a pre-release suffix, like "v1.0.0-rc.1".
+func isPrereleaseVersion(version string) bool {
+	parsed, err := versioning.NewVersion(version)
+	return err == nil && parsed.Prerelease() != ""
+}
+
+type versionChoice struct {
+	Bump    versionBump
+	Version string
+}
+
+var prereleaseCounterRegex = regexp.MustCompile(`^(.*?)([0-9]+)$`)
+
+// nextVersionChoices computes the next version for each bump kind from `latest`. When `latest` is
+// a pre-release, the final choice is the version of that pre-release, the pre-release choice
+// increments its counter and the major and minor choices are only offered when they differ from
+// the final version (there is no patch choice, it would skip the final version). Otherwise, the
+// pre-release choice is the first release candidate of the `recommended` bump.
+func nextVersionChoices(latest *versioning.Version, recommended versionBump) []*versionChoice {
+	segments := latest.Segments()
+	major, minor, patch := segments[0], segments[1], segments[2]
+
+	version := func(major, minor, patch int, prerelease string) string {
+		out := fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+		if prerelease != "" {
+			out += "-" + prerelease
+		}
+
+		return out
+	}
+
+	if prerelease := latest.Prerelease(); prerelease != "" {
+		var choices []*versionChoice
+		if minor != 0 || patch != 0 {
+			choices = append(choices, &versionChoice{versionBumpMajor, version(major+1, 0, 0, "")})
+		}
+
+		if patch != 0 {
+			choices = append(choices, &versionChoice{versionBumpMinor, version(major, minor+1, 0, "")})
+		}
+
+		next := prerelease + ".1"
+		if groups := prereleaseCounterRegex.FindStringSubmatch(prerelease); groups != nil {
+			counter, _ := strconv.Atoi(groups[2])
+			next = groups[1] + strconv.Itoa(counter+1)
+		}
+
+		return append(choices,
+			&versionChoice{versionBumpFinal, version(major, minor, patch, "")},
+			&versionChoice{versionBumpPrerelease, version(major, minor, patch, next)},
+		)
+	}
+
+	choices := []*versionChoice{
+		{versionBumpMajor, version(major+1, 0, 0, "")},
+		{versionBumpMinor, version(major, minor+1, 0, "")},
+		{versionBumpPatch, version(major, minor, patch+1, "")},
+	}
+
+	for _, choice := range choices {
+		if choice.Bump == recommended {
+			return append(choices, &versionChoice{versionBumpPrerelease, choice.Version + "-rc.1"})
+		}
+	}
+
+	return choices
+}
+
+// nextReleaseCandidate returns the release candidate of `version` (like 'v1.3.0') following the
+// highest one found in `tags`, '<version>-rc.1' if none of them is a release candidate of it.
+// Tags not starting with `tagPrefix` are ignored, the 'v' prefix of the versions is optional.
+func nextReleaseCandidate(version string, tagPrefix string, tags []string) string {
+	counter := 0
+	for _, tag := range tags {
+		tagVersion, found := strings.CutPrefix(tag, tagPrefix)
+		if !found {
+			continue
+		}
+
+		suffix, found := strings.CutPrefix(strings.TrimPrefix(tagVersion, "v"), strings.TrimPrefix(version, "v")+"-rc.")
+		if !found {
+			continue
+		}
+
+		if candidate, err := strconv.Atoi(suffix); err == nil && candidate > counter {
+			counter = candidate
+		}
+	}
+
+	return fmt.Sprintf("%s-rc.%d", version, counter+1)
+}
+
+func findVersionChoice(choices []*versionChoice, bump versionBump) *versionChoice {
+	for _, choice := range choices {
+		if choice.Bump == bump {
+			return choice
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"testing"
+
+	versioning "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recommendVersionBump(t *testing.T) {
+	tests := []struct {
+		name       string
+		unreleased *ChangelogRelease
+		commits    []*conventionalCommit
+		want       *bumpRecommendation
+	}{
+		{
+			"nothing",
+			nil,
+			nil,
+			&bumpRecommendation{versionBumpPatch, "no breaking changes nor new features found"},
+		},
+		{
+			"changelog added section",
+			&ChangelogRelease{Sections: []*ChangelogSection{{Name: "Fixed"}, {Name: "Added"}}},
+			[]*conventionalCommit{{Type: "fix", Subject: "crash"}},
+			&bumpRecommendation{versionBumpMinor, `changelog 'Unreleased' has an "Added" section`},
+		},
+		{
+			"changelog removed section wins over feat commits",
+			&ChangelogRelease{Sections: []*ChangelogSection{{Name: "Added"}, {Name: "Removed"}}},
+			[]*conventionalCommit{{Type: "feat", Subject: "new flag"}},
+			&bumpRecommendation{versionBumpMajor, `changelog 'Unreleased' has a "Removed" section`},
+		},
+		{
+			"feat commits",
+			&ChangelogRelease{Sections: []*ChangelogSection{{Name: "Fixed"}}},
+			[]*conventionalCommit{{Type: "fix", Subject: "crash"}, {Type: "feat", Subject: "new flag"}, {Type: "feat", Subject: "other flag"}},
+			&bumpRecommendation{versionBumpMinor, `2 'feat' commit(s) since latest tag, like "new flag"`},
+		},
+		{
+			"breaking commit",
+			nil,
+			[]*conventionalCommit{{Type: "feat", Subject: "new flag"}, {Type: "refactor", Subject: "dropped config", Breaking: true}},
+			&bumpRecommendation{versionBumpMajor, `1 breaking commit(s) since latest tag, like "dropped config"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, recommendVersionBump(tt.unreleased, tt.commits))
+		})
+	}
+}
+
+func Test_nextVersionChoices(t *testing.T) {
+	tests := []struct {
+		latest      string
+		recommended versionBump
+		want        []*versionChoice
+	}{
+		{"v1.2.3", versionBumpMinor, []*versionChoice{
+			{versionBumpMajor, "v2.0.0"},
+			{versionBumpMinor, "v1.3.0"},
+			{versionBumpPatch, "v1.2.4"},
+			{versionBumpPrerelease, "v1.3.0-rc.1"},
+		}},
+		{"v1.3.0-rc.2", versionBumpFinal, []*versionChoice{
+			{versionBumpMajor, "v2.0.0"},
+			{versionBumpFinal, "v1.3.0"},
+			{versionBumpPrerelease, "v1.3.0-rc.3"},
+		}},
+		{"v1.3.2-rc.1", versionBumpFinal, []*versionChoice{
+			{versionBumpMajor, "v2.0.0"},
+			{versionBumpMinor, "v1.4.0"},
+			{versionBumpFinal, "v1.3.2"},
+			{versionBumpPrerelease, "v1.3.2-rc.2"},
+		}},
+		{"v2.0.0-beta", versionBumpFinal, []*versionChoice{
+			{versionBumpFinal, "v2.0.0"},
+			{versionBumpPrerelease, "v2.0.0-beta.1"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.latest, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextVersionChoices(versioning.Must(versioning.NewVersion(tt.latest)), tt.recommended))
+		})
+	}
+}
+
+func Test_nextReleaseCandidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		tagPrefix string
+		tags      []string
+		want      string
+	}{
+		{"no tags", "v1.3.0", "", nil, "v1.3.0-rc.1"},
+		{"existing release candidates", "v1.3.0", "", []string{"v1.3.0-rc.1", "v1.3.0-rc.2"}, "v1.3.0-rc.3"},
+		{"unordered and without 'v'", "v1.3.0", "", []string{"1.3.0-rc.10", "v1.3.0-rc.2"}, "v1.3.0-rc.11"},
+		{"other versions ignored", "v1.3.0", "", []string{"v11.3.0-rc.4", "v1.3.0-rc.x", "v1.3.0-beta.1"}, "v1.3.0-rc.1"},
+		{"tag prefix", "v1.3.0", "app/", []string{"app/v1.3.0-rc.1", "other/v1.3.0-rc.5"}, "v1.3.0-rc.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextReleaseCandidate(tt.version, tt.tagPrefix, tt.tags))
+		})
+	}
+}
+
+func Test_isPrereleaseVersion(t *testing.T) {
+	assert.False(t, isPrereleaseVersion("v1.0.0"))
+	assert.True(t, isPrereleaseVersion("v1.0.0-rc.1"))
+	assert.True(t, isPrereleaseVersion("v1.0.0-beta"))
+	assert.False(t, isPrereleaseVersion("invalid"))
+}
+
+func TestReleaseModel_setVersion(t *testing.T) {
+	release := &ReleaseModel{Brew: &BrewReleaseModel{}}
+	release.setVersion("v1.0.0")
+	assert.False(t, release.Prerelease)
+	assert.False(t, release.Brew.Disabled)
+
+	release = &ReleaseModel{Brew: &BrewReleaseModel{}}
+	release.setVersion("v1.0.0-rc.1")
+	assert.True(t, release.Prerelease)
+	assert.True(t, release.Brew.Disabled)
+
+	release = &ReleaseModel{Brew: &BrewReleaseModel{Prerelease: true}}
+	release.setVersion("v1.0.0-rc.1")
+	assert.True(t, release.Prerelease)
+	assert.False(t, release.Brew.Disabled)
+}
@@ -2,6 +2,10 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -9,8 +13,52 @@ import (
 	"go.uber.org/zap"
 )
 
+// configFileErr is the error that occurred loading the '.sfreleaser' config file, it's only
+// recorded for the commands diagnosing the config file (see [reportsConfigFileErr]), other
+// commands fail right away.
+var configFileErr error
+
+func reportsConfigFileErr(cmd *cobra.Command) bool {
+	return cmd.Name() == "doctor" || (cmd.Name() == "validate" && cmd.Parent().Name() == "config")
+}
+
+// activeProfile is the profile selected through '--profile' (or 'global.profile'), its values
+// are merged over the ones of the '.sfreleaser' config file, see [applyConfigProfile].
+var activeProfile string
+
+// configProfileSections are the sections a profile can overlay.
+var configProfileSections = []string{"global", "release", "build"}
+
+// applyConfigProfile merges the values of `profile`, defined under 'profiles.<profile>' in the
+// config file, over the config file values. Flags and environment variables still win over them.
+func applyConfigProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
+
+	if !viper.InConfig("profiles." + profile) {
+		available := slices.Sorted(maps.Keys(viper.GetStringMap("profiles")))
+		return fmt.Errorf("profile %q is not defined in the 'profiles' section of the '.sfreleaser' config file (available profiles: %s)", profile, orNone(available))
+	}
+
+	overlay := viper.GetStringMap("profiles." + profile)
+	for section := range overlay {
+		if !slices.Contains(configProfileSections, section) {
+			return fmt.Errorf("profile %q defines section %q, only %s can be overlaid by a profile", profile, section, strings.Join(configProfileSections, ", "))
+		}
+	}
+
+	zlog.Debug("applying config profile", zap.String("profile", profile), zap.Reflect("overlay", overlay))
+	if err := viper.MergeConfigMap(overlay); err != nil {
+		return fmt.Errorf("merge profile %q: %w", profile, err)
+	}
+
+	activeProfile = profile
+	return nil
+}
+
 func ConfigureReleaserConfigFile() cli.CommandOption {
-	configurer := func(_ *cobra.Command, _ []string) {
+	configurer := func(cmd *cobra.Command, _ []string) {
 		configIn := "."
 		if viper.GetString("global.root") != "" {
 			configIn = viper.GetString("global.root")
@@ -24,9 +72,25 @@ func ConfigureReleaserConfigFile() cli.CommandOption {
 		if err := viper.ReadInConfig(); err != nil {
 			notFoundErr := viper.ConfigFileNotFoundError{}
 			if !errors.As(err, &notFoundErr) {
+				if reportsConfigFileErr(cmd) {
+					zlog.Debug("loading config file failed, command will report it", zap.String("command", cmd.CommandPath()), zap.Error(err))
+					configFileErr = err
+					return
+				}
+
 				cli.NoError(err, "Loading config file failed")
 			}
 		}
+
+		if err := applyConfigProfile(viper.GetString("global.profile")); err != nil {
+			if reportsConfigFileErr(cmd) {
+				zlog.Debug("applying profile failed, command will report it", zap.String("command", cmd.CommandPath()), zap.Error(err))
+				configFileErr = err
+				return
+			}
+
+			cli.NoError(err, "Applying profile failed")
+		}
 	}
 
 	return cli.CommandOptionFunc(func(cmd *cobra.Command) {
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyConfigProfile(t *testing.T) {
+	config := dedent(`
+		global:
+		  language: golang
+		release:
+		  brew-disabled: true
+		  upload-extra-assets: [a.txt]
+		profiles:
+		  public:
+		    release:
+		      brew-disabled: false
+		      upload-extra-assets: [b.txt]
+		  invalid:
+		    changelog:
+		      lint: {}
+	`)
+
+	tests := []struct {
+		name         string
+		profile      string
+		wantErr      string
+		wantProfile  string
+		wantDisabled bool
+		wantAssets   []string
+		wantLanguage string
+	}{
+		{"no profile", "", "", "", true, []string{"a.txt"}, "golang"},
+		{"profile overlays values", "public", "", "public", false, []string{"b.txt"}, "golang"},
+		{"unknown profile", "internal", `profile "internal" is not defined in the 'profiles' section of the '.sfreleaser' config file (available profiles: invalid, public)`, "", true, []string{"a.txt"}, "golang"},
+		{"unsupported section", "invalid", `profile "invalid" defines section "changelog", only global, release, build can be overlaid by a profile`, "", true, []string{"a.txt"}, "golang"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				viper.Reset()
+				activeProfile = ""
+			})
+
+			viper.SetConfigType("yaml")
+			require.NoError(t, viper.ReadConfig(strings.NewReader(config)))
+
+			err := applyConfigProfile(tt.profile)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantProfile, activeProfile)
+			assert.Equal(t, tt.wantDisabled, viper.GetBool("release.brew-disabled"))
+			assert.Equal(t, tt.wantAssets, viper.GetStringSlice("release.upload-extra-assets"))
+			assert.Equal(t, tt.wantLanguage, viper.GetString("global.language"))
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Package github is a minimal GitHub REST API client covering the operations
+// 'sfreleaser' needs to manage releases (lookup, deletion, asset upload and
+// publishing).
+//
+// Requests are retried on server errors (5xx) as well as on primary and
+// secondary rate limits. Network errors are only retried for idempotent
+// requests, an upload is never sent twice. Redirects (307 for example when a repository has been
+// renamed) are followed transparently, including for uploads.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultBaseURL   = "https://api.github.com"
+	DefaultUploadURL = "https://uploads.github.com"
+
+	apiVersion = "2022-11-28"
+	userAgent  = "sfreleaser"
+)
+
+// ErrNotFound is returned when the requested resource does not exist (or is not
+// visible with the credentials used).
+var ErrNotFound = errors.New("not found")
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	uploadURL  string
+	token      string
+
+	maxRetries    int
+	minRetryDelay time.Duration
+	maxRetryDelay time.Duration
+	sleep         func(ctx context.Context, delay time.Duration) error
+}
+
+type Option func(c *Client)
+
+// WithBaseURL overrides the API base URL, defaults to [DefaultBaseURL].
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithUploadURL overrides the upload base URL used when a release does not
+// provide its own upload URL, defaults to [DefaultUploadURL].
+func WithUploadURL(uploadURL string) Option {
+	return func(c *Client) {
+		c.uploadURL = strings.TrimSuffix(uploadURL, "/")
+	}
+}
+
+// WithHTTPClient overrides the [http.Client] used to perform requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetry configures how many times a retryable request is retried and the
+// minimum/maximum delay of the exponential backoff between attempts.
+func WithRetry(maxRetries int, minDelay time.Duration, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.minRetryDelay = minDelay
+		c.maxRetryDelay = maxDelay
+	}
+}
+
+func NewClient(token string, opts ...Option) *Client {
+	client := &Client{
+		httpClient:    &http.Client{Timeout: 30 * time.Minute},
+		baseURL:       DefaultBaseURL,
+		uploadURL:     DefaultUploadURL,
+		token:         token,
+		maxRetries:    5,
+		minRetryDelay: 1 * time.Second,
+		maxRetryDelay: 1 * time.Minute,
+		sleep:         sleepContext,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// Error is returned when GitHub API responds with an unexpected status code.
+type Error struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+type requestFactory func(ctx context.Context) (*http.Request, error)
+
+// do performs the request created by `newRequest`, retrying on retryable failures, and
+// decodes the JSON response into `out` (if non-nil). The factory is invoked on each
+// attempt since a request body cannot be re-used once consumed.
+func (c *Client) do(ctx context.Context, newRequest requestFactory, out any) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("new request: %w", err)
+		}
+
+		c.decorate(req)
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.maxRetries || !isRetryableTransportError(req.Method, err) {
+				return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL, err)
+			}
+
+			delay := c.backoff(attempt)
+			zlog.Debug("request failed, retrying", zap.String("method", req.Method), zap.Stringer("url", req.URL), zap.Duration("delay", delay), zap.Error(err))
+			if err := c.sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		zlog.Debug("request completed", zap.String("method", req.Method), zap.Stringer("url", req.URL), zap.Int("status", resp.StatusCode), zap.Duration("took", time.Since(start)))
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			defer resp.Body.Close()
+
+			if out != nil && resp.StatusCode != http.StatusNoContent {
+				if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+					return resp, fmt.Errorf("decode %s %s response: %w", req.Method, req.URL, err)
+				}
+			}
+
+			return resp, nil
+		}
+
+		apiErr := newError(req, resp)
+		if delay, retryable := c.retryDelay(resp, apiErr, attempt); retryable && attempt < c.maxRetries {
+			zlog.Debug("retryable response received, retrying", zap.Error(apiErr), zap.Duration("delay", delay), zap.Int("attempt", attempt+1))
+			if err := c.sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		return resp, apiErr
+	}
+}
+
+// isRetryableTransportError determines if a request that failed with transport error `err` can
+// be sent again. GitHub may have processed a request whose response was lost (an asset upload
+// for example), so only idempotent requests are retried unless the connection was never made.
+func isRetryableTransportError(method string, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (c *Client) decorate(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	req.Header.Set("User-Agent", userAgent)
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+// retryDelay determines if the response is retryable and if yes, how long to wait before
+// the next attempt. Server errors are retried as well as primary and secondary rate limits,
+// see https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api.
+func (c *Client) retryDelay(resp *http.Response, apiErr *Error, attempt int) (time.Duration, bool) {
+	if resp.StatusCode >= 500 {
+		return c.backoff(attempt), true
+	}
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), c.minRetryDelay), true
+		}
+
+		return c.backoff(attempt), true
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || strings.Contains(strings.ToLower(apiErr.Message), "secondary rate limit") {
+		// GitHub recommends waiting at least a minute when no other hint is given
+		return max(c.backoff(attempt), time.Minute), true
+	}
+
+	return 0, false
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := time.Duration(float64(c.minRetryDelay) * math.Pow(2, float64(attempt)))
+	if delay <= 0 || delay > c.maxRetryDelay {
+		return c.maxRetryDelay
+	}
+
+	return delay
+}
+
+func newError(req *http.Request, resp *http.Response) *Error {
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode, Method: req.Method, URL: req.URL.String()}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return apiErr
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Resource string `json:"resource"`
+			Field    string `json:"field"`
+			Code     string `json:"code"`
+			Message  string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+
+	apiErr.Message = payload.Message
+	for _, detail := range payload.Errors {
+		apiErr.Message += fmt.Sprintf(" [Resource:%s Field:%s Code:%s Message:%s]", detail.Resource, detail.Field, detail.Code, detail.Message)
+	}
+
+	return apiErr
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,225 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("token", WithBaseURL(server.URL), WithUploadURL(server.URL), WithRetry(3, time.Millisecond, time.Millisecond))
+	client.sleep = func(ctx context.Context, delay time.Duration) error { return nil }
+
+	return client, server
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, body any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(t, json.NewEncoder(w).Encode(body))
+}
+
+func TestClient_FindRelease(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		if r.URL.Query().Get("page") == "1" {
+			releases := make([]*Release, releasesPerPage)
+			for i := range releases {
+				releases[i] = &Release{ID: int64(i), TagName: fmt.Sprintf("v0.0.%d", i)}
+			}
+
+			writeJSON(t, w, http.StatusOK, releases)
+			return
+		}
+
+		writeJSON(t, w, http.StatusOK, []*Release{{ID: 1000, TagName: "v1.0.0", Draft: true, HTMLURL: "https://github.com/owner/repo/releases/tag/untagged-1"}})
+	})
+
+	client, _ := newTestClient(t, mux)
+
+	release, err := client.FindRelease(context.Background(), "owner", "repo", "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), release.ID)
+	assert.True(t, release.Draft)
+
+	_, err = client.FindRelease(context.Background(), "owner", "repo", "v2.0.0")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_RetryOnServerErrorsAndRateLimits(t *testing.T) {
+	var calls atomic.Int32
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			writeJSON(t, w, http.StatusBadGateway, map[string]any{"message": "Server Error"})
+		case 2:
+			writeJSON(t, w, http.StatusForbidden, map[string]any{"message": "You have exceeded a secondary rate limit"})
+		case 3:
+			w.Header().Set("Retry-After", "0")
+			writeJSON(t, w, http.StatusTooManyRequests, map[string]any{"message": "Too many requests"})
+		default:
+			writeJSON(t, w, http.StatusOK, &Release{ID: 1, Draft: false})
+		}
+	}))
+
+	release, err := client.PublishRelease(context.Background(), "owner", "repo", 1)
+	require.NoError(t, err)
+	assert.False(t, release.Draft)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestClient_NoRetryOnClientErrors(t *testing.T) {
+	var calls atomic.Int32
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		writeJSON(t, w, http.StatusUnprocessableEntity, map[string]any{
+			"message": "Validation Failed",
+			"errors":  []map[string]any{{"resource": "Release", "field": "target_commitish", "code": "invalid"}},
+		})
+	}))
+
+	err := client.DeleteRelease(context.Background(), "owner", "repo", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "422 Unprocessable Entity: Validation Failed [Resource:Release Field:target_commitish Code:invalid Message:]")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_TransportErrorRetries(t *testing.T) {
+	var calls atomic.Int32
+
+	// Closes the connection without responding, the request may or may not have been processed
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+
+	t.Run("idempotent request is retried", func(t *testing.T) {
+		calls.Store(0)
+
+		_, err := client.FindRelease(context.Background(), "owner", "repo", "v1.0.0")
+		require.Error(t, err)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+
+	t.Run("non-idempotent request is not retried", func(t *testing.T) {
+		calls.Store(0)
+
+		assetPath := filepath.Join(t.TempDir(), "package.spkg")
+		require.NoError(t, os.WriteFile(assetPath, []byte("content"), 0644))
+
+		_, err := client.UploadReleaseAsset(context.Background(), "owner", "repo", &Release{ID: 1}, assetPath)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestClient_UploadReleaseAsset_FollowsRedirect(t *testing.T) {
+	assetPath := filepath.Join(t.TempDir(), "package.spkg")
+	require.NoError(t, os.WriteFile(assetPath, []byte("content"), 0644))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/old-owner/repo/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/repos/owner/repo/releases/1/assets?"+r.URL.RawQuery, http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("POST /repos/owner/repo/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "package.spkg", r.URL.Query().Get("name"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(body))
+
+		writeJSON(t, w, http.StatusCreated, &Asset{ID: 2, Name: "package.spkg", Size: int64(len(body))})
+	})
+
+	client, server := newTestClient(t, mux)
+
+	asset, err := client.UploadReleaseAsset(context.Background(), "old-owner", "repo", &Release{
+		ID:        1,
+		UploadURL: server.URL + "/repos/old-owner/repo/releases/1/assets{?name,label}",
+	}, assetPath)
+	require.NoError(t, err)
+	assert.Equal(t, &Asset{ID: 2, Name: "package.spkg", Size: 7}, asset)
+}
+
+func TestClient_CommitExists(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/owner/repo/commits/known", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]any{"sha": "known"})
+	})
+	mux.HandleFunc("GET /repos/owner/repo/commits/unknown", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusUnprocessableEntity, map[string]any{"message": "No commit found for SHA: unknown"})
+	})
+
+	client, _ := newTestClient(t, mux)
+
+	exists, err := client.CommitExists(context.Background(), "owner", "repo", "known")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.CommitExists(context.Background(), "owner", "repo", "unknown")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestClient_AuthenticatedTokenInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer classic" {
+			w.Header().Set("X-OAuth-Scopes", "repo, workflow")
+		}
+
+		writeJSON(t, w, http.StatusOK, map[string]any{"login": "octocat"})
+	})
+
+	_, server := newTestClient(t, mux)
+
+	info, err := NewClient("classic", WithBaseURL(server.URL)).AuthenticatedTokenInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TokenInfo{User: &User{Login: "octocat"}, Scopes: []string{"repo", "workflow"}}, info)
+
+	info, err = NewClient("fine-grained", WithBaseURL(server.URL)).AuthenticatedTokenInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TokenInfo{User: &User{Login: "octocat"}}, info)
+}
+
+func TestClient_GetRepository_FollowsRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/owner/old-name", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/repositories/42", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("GET /repositories/42", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]any{"full_name": "owner/new-name", "html_url": "https://github.com/owner/new-name"})
+	})
+
+	client, _ := newTestClient(t, mux)
+
+	repository, err := client.GetRepository(context.Background(), "owner", "old-name")
+	require.NoError(t, err)
+	assert.Equal(t, "owner/new-name", repository.FullName)
+}
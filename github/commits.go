@@ -0,0 +1,27 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CommitExists returns true if GitHub knows about the commit `sha` in the repository. Right
+// after a push, GitHub can take a few moments before the commit is visible to all its
+// services, most notably the release one.
+func (c *Client) CommitExists(ctx context.Context, owner, repo, sha string) (bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/commits/%s", c.baseURL, owner, repo, sha)
+
+	_, err := c.do(ctx, jsonRequest(http.MethodGet, endpoint, nil), nil)
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("get commit: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,7 @@
+package github
+
+import (
+	"github.com/streamingfast/logging"
+)
+
+var zlog, _ = logging.PackageLogger("github", "github.com/streamingfast/sfreleaser/github")
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Release struct {
+	ID         int64    `json:"id"`
+	TagName    string   `json:"tag_name"`
+	Name       string   `json:"name"`
+	Draft      bool     `json:"draft"`
+	Prerelease bool     `json:"prerelease"`
+	HTMLURL    string   `json:"html_url"`
+	UploadURL  string   `json:"upload_url"`
+	Assets     []*Asset `json:"assets"`
+}
+
+type Asset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// ReleaseEdit lists the fields of a release to update, nil fields are left untouched.
+type ReleaseEdit struct {
+	Draft      *bool  `json:"draft,omitempty"`
+	Prerelease *bool  `json:"prerelease,omitempty"`
+	MakeLatest string `json:"make_latest,omitempty"`
+}
+
+const releasesPerPage = 100
+
+// FindRelease returns the release (draft or not) whose tag is `tag`. The releases are listed
+// instead of using the "release by tag" endpoint because the latter never returns draft releases.
+//
+// Returns [ErrNotFound] if no release exists for this tag.
+func (c *Client) FindRelease(ctx context.Context, owner, repo, tag string) (*Release, error) {
+	for page := 1; ; page++ {
+		var releases []*Release
+		endpoint := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d&page=%d", c.baseURL, owner, repo, releasesPerPage, page)
+
+		if _, err := c.do(ctx, jsonRequest(http.MethodGet, endpoint, nil), &releases); err != nil {
+			return nil, fmt.Errorf("list releases: %w", err)
+		}
+
+		for _, release := range releases {
+			if release.TagName == tag {
+				return release, nil
+			}
+		}
+
+		if len(releases) < releasesPerPage {
+			return nil, fmt.Errorf("release %q in %s/%s: %w", tag, owner, repo, ErrNotFound)
+		}
+	}
+}
+
+func (c *Client) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases/%d", c.baseURL, owner, repo, id)
+	if _, err := c.do(ctx, jsonRequest(http.MethodDelete, endpoint, nil), nil); err != nil {
+		return fmt.Errorf("delete release: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) EditRelease(ctx context.Context, owner, repo string, id int64, edit *ReleaseEdit) (*Release, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases/%d", c.baseURL, owner, repo, id)
+
+	release := &Release{}
+	if _, err := c.do(ctx, jsonRequest(http.MethodPatch, endpoint, edit), release); err != nil {
+		return nil, fmt.Errorf("edit release: %w", err)
+	}
+
+	return release, nil
+}
+
+// PublishRelease transitions a draft release to a published one.
+func (c *Client) PublishRelease(ctx context.Context, owner, repo string, id int64) (*Release, error) {
+	draft := false
+	return c.EditRelease(ctx, owner, repo, id, &ReleaseEdit{Draft: &draft})
+}
+
+// UploadReleaseAsset uploads the file at `path` to the release, the asset is named after the
+// file's base name.
+func (c *Client) UploadReleaseAsset(ctx context.Context, owner, repo string, release *Release, path string) (*Asset, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat asset: %w", err)
+	}
+
+	if stat.IsDir() {
+		return nil, fmt.Errorf("asset %q is a directory", path)
+	}
+
+	endpoint := c.releaseUploadURL(owner, repo, release) + "?name=" + url.QueryEscape(filepath.Base(path))
+
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		// Enables following 307/308 redirects which requires re-sending the body
+		req.GetBody = func() (io.ReadCloser, error) { return os.Open(path) }
+		req.ContentLength = stat.Size()
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		return req, nil
+	}
+
+	asset := &Asset{}
+	if _, err := c.do(ctx, newRequest, asset); err != nil {
+		return nil, fmt.Errorf("upload asset %q: %w", filepath.Base(path), err)
+	}
+
+	return asset, nil
+}
+
+// releaseUploadURL returns the upload URL of the release, GitHub gives it as an hypermedia
+// template like "https://uploads.github.com/repos/o/r/releases/1/assets{?name,label}".
+func (c *Client) releaseUploadURL(owner, repo string, release *Release) string {
+	if release.UploadURL != "" {
+		if index := strings.Index(release.UploadURL, "{"); index != -1 {
+			return release.UploadURL[:index]
+		}
+
+		return release.UploadURL
+	}
+
+	return fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets", c.uploadURL, owner, repo, release.ID)
+}
+
+func jsonRequest(method string, endpoint string, body any) requestFactory {
+	return func(ctx context.Context) (*http.Request, error) {
+		var reader io.Reader
+		if body != nil {
+			content, err := json.Marshal(body)
+			if err != nil {
+				return nil, fmt.Errorf("encode body: %w", err)
+			}
+
+			// bytes.Reader makes http.NewRequest populate GetBody so redirects can be followed
+			reader = bytes.NewReader(content)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		return req, nil
+	}
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type User struct {
+	Login string `json:"login"`
+}
+
+// TokenInfo describes the identity and the permissions of the token used by the client.
+type TokenInfo struct {
+	User *User
+	// Scopes are the OAuth scopes of a classic personal access token, they are nil for other
+	// kind of tokens (fine-grained or GitHub Actions tokens) which do not report them.
+	Scopes []string
+}
+
+// AuthenticatedTokenInfo returns the user and scopes of the token used by the client, it
+// returns an error if the token is invalid or expired.
+func (c *Client) AuthenticatedTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	user := &User{}
+	resp, err := c.do(ctx, jsonRequest(http.MethodGet, c.baseURL+"/user", nil), user)
+	if err != nil {
+		return nil, fmt.Errorf("get authenticated user: %w", err)
+	}
+
+	info := &TokenInfo{User: user}
+	if header, found := resp.Header["X-Oauth-Scopes"]; found {
+		info.Scopes = []string{}
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				info.Scopes = append(info.Scopes, scope)
+			}
+		}
+	}
+
+	return info, nil
+}
+
+type Repository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// GetRepository returns the repository `owner/repo`. Redirects are followed, so for a renamed
+// or transferred repository, the returned [Repository.FullName] is the new name.
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*Repository, error) {
+	repository := &Repository{}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, repo)
+
+	if _, err := c.do(ctx, jsonRequest(http.MethodGet, endpoint, nil), repository); err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+
+	return repository, nil
+}